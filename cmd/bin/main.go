@@ -16,6 +16,7 @@ func main() {
 
 	serverCmd := flag.NewFlagSet("server", flag.ExitOnError)
 	seedCmd := flag.NewFlagSet("seed", flag.ExitOnError)
+	importCmd := flag.NewFlagSet("import", flag.ExitOnError)
 	// wsCmd := flag.NewFlagSet("ws", flag.ExitOnError)
 
 	if len(os.Args) < 2 {
@@ -27,6 +28,8 @@ func main() {
 	switch os.Args[1] {
 	case "seed":
 		cmd.RunSeed(seedCmd, os.Args[2:])
+	case "import":
+		cmd.RunImport(importCmd, os.Args[2:])
 	case "server":
 		cmd.RunServer(serverCmd, os.Args[2:])
 	default:
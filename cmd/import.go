@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/adapter"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/repository"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/service"
+	shopRepository "github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/repository"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/validator"
+)
+
+func RunImport(cmd *flag.FlagSet, args []string) {
+	var (
+		file   = cmd.String("file", "", "CSV or NDJSON file of products")
+		format = cmd.String("format", "", "file format, csv or ndjson (default: from the file extension)")
+		shopId = cmd.String("shop_id", "", "shop of the rows without shop_id")
+		userId = cmd.String("user_id", "", "owner of the shops, recorded as the actor of the initial stock")
+		report = cmd.String("report", "", "file to write the report to (default: stdout)")
+	)
+
+	if err := cmd.Parse(args); err != nil {
+		log.Fatal().Err(err).Msg("Error while parsing flags")
+	}
+
+	if *format == "" {
+		*format = entity.ImportFormat(*file)
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error while opening import file")
+	}
+	defer f.Close()
+
+	adapter.Adapters.Sync(
+		adapter.WithShopeefunPostgres(),
+		adapter.WithValidator(validator.NewValidator()),
+	)
+	defer func() {
+		if err := adapter.Adapters.Unsync(); err != nil {
+			log.Fatal().Err(err).Msg("Error while closing database connection")
+		}
+	}()
+
+	var (
+		repo     = repository.NewProductRepository(adapter.Adapters.ShopeefunPostgres)
+		shopRepo = shopRepository.NewShopRepository(adapter.Adapters.ShopeefunPostgres)
+		svc      = service.NewProductService(repo, shopRepo, nil, adapter.Adapters.Validator)
+		req      = &entity.ImportProductsRequest{
+			UserId: *userId,
+			Format: *format,
+			ShopId: *shopId,
+			File:   f,
+		}
+	)
+
+	if err := adapter.Adapters.Validator.Validate(req); err != nil {
+		_, errs := errmsg.Errors(err, req)
+		log.Error().Any("errors", errs).Msg("Invalid import flags")
+		return
+	}
+
+	resp, err := svc.ImportProducts(context.Background(), req)
+	if err != nil {
+		_, errs := errmsg.Errors[error](err)
+		log.Error().Err(err).Any("errors", errs).Msg("Error while importing products")
+		return
+	}
+
+	out := os.Stdout
+	if *report != "" {
+		out, err = os.Create(*report)
+		if err != nil {
+			log.Error().Err(err).Msg("Error while creating report file")
+			return
+		}
+		defer out.Close()
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(resp); err != nil {
+		log.Error().Err(err).Msg("Error while writing report")
+		return
+	}
+
+	log.Info().
+		Int("total", resp.Total).
+		Int("accepted", len(resp.Accepted)).
+		Int("rejected", len(resp.Rejected)).
+		Msg("Products imported")
+}
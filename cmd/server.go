@@ -17,6 +17,7 @@ import (
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/infrastructure"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/infrastructure/config"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/route"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/scheduler"
 	"github.com/hilmiikhsan/shopeefun-product-service/pkg/validator"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -44,7 +45,9 @@ func RunServer(cmd *flag.FlagSet, args []string) {
 		SERVER_PORT = *flagAppPort
 	}
 
-	app := fiber.New()
+	app := fiber.New(fiber.Config{
+		BodyLimit: envs.App.BodyLimit * 1024 * 1024,
+	})
 
 	// Application Middlewares
 	if envs.App.Environtment == "production" {
@@ -55,21 +58,26 @@ func RunServer(cmd *flag.FlagSet, args []string) {
 	}
 
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowMethods: "GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD",
-		AllowHeaders: "Origin,Content-Type,Accept,Content-Length,Accept-Language,Accept-Encoding,Connection,Access-Control-Allow-Origin,Authorization",
+		AllowOrigins:  "*",
+		AllowMethods:  "GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD",
+		AllowHeaders:  "Origin,Content-Type,Accept,Content-Length,Accept-Language,Accept-Encoding,Connection,Access-Control-Allow-Origin,Authorization,If-Match",
+		ExposeHeaders: "ETag",
 	}))
 	// End Application Middlewares
 
 	adapter.Adapters.Sync(
 		adapter.WithRestServer(app),
 		adapter.WithShopeefunPostgres(),
+		adapter.WithShopeefunStorage(),
 		adapter.WithValidator(validator.NewValidator()),
+		adapter.WithPublisher(),
+		adapter.WithScheduler(scheduler.New()),
 	)
 
 	infrastructure.InitializeLogger(envs.App.Environtment, envs.App.LogFile, logLevel)
 	app.Get("/metrics", monitor.New(monitor.Config{Title: config.Envs.App.Name + config.Envs.App.Environtment + " Metrics"}))
 	route.SetupRoutes(app)
+	route.SetupJobs(adapter.Adapters.Scheduler)
 
 	// print all routes that are registered
 	for _, route := range app.Stack() {
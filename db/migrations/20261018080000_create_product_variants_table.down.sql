@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_products_total_stock;
+DROP INDEX IF EXISTS idx_products_max_price;
+DROP INDEX IF EXISTS idx_products_min_price;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS total_stock,
+    DROP COLUMN IF EXISTS max_price,
+    DROP COLUMN IF EXISTS min_price;
+
+DROP TABLE IF EXISTS product_variants;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS product_variants (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id),
+    sku VARCHAR(100) NOT NULL,
+    options JSONB NOT NULL DEFAULT '[]'::jsonb,
+    price DECIMAL(10,2) NOT NULL,
+    stock INTEGER NOT NULL DEFAULT 0 CHECK (stock >= 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_product_variants_product_id ON product_variants(product_id) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_product_variants_product_id_sku ON product_variants(product_id, sku) WHERE deleted_at IS NULL;
+
+-- price range and total stock across variants, kept in sync by the product repository.
+-- products without variants mirror their own price and stock.
+ALTER TABLE products
+    ADD COLUMN min_price DECIMAL(10,2),
+    ADD COLUMN max_price DECIMAL(10,2),
+    ADD COLUMN total_stock INTEGER;
+
+UPDATE products SET min_price = price, max_price = price, total_stock = stock;
+
+ALTER TABLE products
+    ALTER COLUMN min_price SET NOT NULL,
+    ALTER COLUMN max_price SET NOT NULL,
+    ALTER COLUMN total_stock SET NOT NULL;
+
+CREATE INDEX idx_products_min_price ON products(min_price);
+CREATE INDEX idx_products_max_price ON products(max_price);
+CREATE INDEX idx_products_total_stock ON products(total_stock);
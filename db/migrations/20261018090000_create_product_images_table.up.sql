@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS product_images (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id),
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    position INTEGER NOT NULL,
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    is_private BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL
+);
+
+CREATE INDEX idx_product_images_product_id_position ON product_images(product_id, position);
+CREATE UNIQUE INDEX idx_product_images_product_id_primary ON product_images(product_id) WHERE is_primary;
@@ -0,0 +1,10 @@
+-- 'simple' config keeps words as they are, the catalog mixes indonesian and english so no stemming is applied.
+ALTER TABLE products
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(brand, '')), 'B') ||
+        setweight(to_tsvector('simple', coalesce(category, '')), 'B') ||
+        setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+    ) STORED;
+
+CREATE INDEX idx_products_search_vector ON products USING GIN(search_vector);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_products_name_id;
+DROP INDEX IF EXISTS idx_products_rating_id;
+DROP INDEX IF EXISTS idx_products_min_price_id;
+DROP INDEX IF EXISTS idx_products_created_at_id;
+
+CREATE INDEX idx_products_min_price ON products(min_price);
+CREATE INDEX idx_products_rating ON products(rating);
+
+ALTER TABLE products ALTER COLUMN rating DROP NOT NULL;
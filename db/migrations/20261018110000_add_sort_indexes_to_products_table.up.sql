@@ -0,0 +1,12 @@
+UPDATE products SET rating = 0 WHERE rating IS NULL;
+
+ALTER TABLE products ALTER COLUMN rating SET NOT NULL;
+
+-- every listing order is (column, id), the indexes are scanned backward for the descending orders
+DROP INDEX IF EXISTS idx_products_rating;
+DROP INDEX IF EXISTS idx_products_min_price;
+
+CREATE INDEX idx_products_created_at_id ON products(created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_products_min_price_id ON products(min_price, id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_products_rating_id ON products(rating, id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_products_name_id ON products(name, id) WHERE deleted_at IS NULL;
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS categories (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    parent_id UUID REFERENCES categories(id),
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(120) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX idx_categories_slug ON categories(slug) WHERE deleted_at IS NULL;
+CREATE INDEX idx_categories_parent_id ON categories(parent_id) WHERE deleted_at IS NULL;
+
+-- turn the free text categories into root categories, spellings that share a slug are merged
+INSERT INTO categories (name, slug)
+SELECT DISTINCT ON (slug) name, slug
+FROM (
+    SELECT
+        trim(category) as name,
+        trim(both '-' from lower(regexp_replace(trim(category), '[^a-zA-Z0-9]+', '-', 'g'))) as slug
+    FROM products
+) c
+WHERE slug <> ''
+ORDER BY slug, name;
+
+ALTER TABLE products ADD COLUMN category_id UUID REFERENCES categories(id);
+
+-- products.category keeps a copy of the category name for search
+UPDATE products p
+SET
+    category_id = c.id,
+    category = c.name
+FROM categories c
+WHERE c.slug = trim(both '-' from lower(regexp_replace(trim(p.category), '[^a-zA-Z0-9]+', '-', 'g')));
+
+DROP INDEX IF EXISTS idx_products_category;
+CREATE INDEX idx_products_category_id ON products(category_id) WHERE deleted_at IS NULL;
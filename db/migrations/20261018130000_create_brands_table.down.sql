@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_products_brand_id;
+CREATE INDEX idx_products_brand ON products(brand);
+
+ALTER TABLE products DROP COLUMN IF EXISTS brand_id;
+
+DROP TABLE IF EXISTS brands;
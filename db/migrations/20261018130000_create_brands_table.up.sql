@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS brands (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(120) NOT NULL,
+    logo_filename VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX idx_brands_slug ON brands(slug) WHERE deleted_at IS NULL;
+
+-- register the free text brands, spellings that share a slug are merged
+INSERT INTO brands (name, slug)
+SELECT DISTINCT ON (slug) name, slug
+FROM (
+    SELECT
+        trim(brand) as name,
+        trim(both '-' from lower(regexp_replace(trim(brand), '[^a-zA-Z0-9]+', '-', 'g'))) as slug
+    FROM products
+    WHERE brand IS NOT NULL
+) b
+WHERE slug <> ''
+ORDER BY slug, name;
+
+ALTER TABLE products ADD COLUMN brand_id UUID REFERENCES brands(id);
+
+-- products.brand keeps a copy of the brand name for search
+UPDATE products p
+SET
+    brand_id = b.id,
+    brand = b.name
+FROM brands b
+WHERE b.slug = trim(both '-' from lower(regexp_replace(trim(p.brand), '[^a-zA-Z0-9]+', '-', 'g')));
+
+DROP INDEX IF EXISTS idx_products_brand;
+CREATE INDEX idx_products_brand_id ON products(brand_id) WHERE deleted_at IS NULL;
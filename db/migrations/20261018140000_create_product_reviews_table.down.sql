@@ -0,0 +1,8 @@
+ALTER TABLE shops DROP COLUMN IF EXISTS review_count;
+ALTER TABLE shops ALTER COLUMN rating DROP NOT NULL;
+ALTER TABLE shops ALTER COLUMN rating TYPE INTEGER USING ROUND(rating);
+
+ALTER TABLE products DROP COLUMN IF EXISTS review_count;
+ALTER TABLE products ALTER COLUMN rating TYPE INTEGER USING ROUND(rating);
+
+DROP TABLE IF EXISTS product_reviews;
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS product_reviews (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id),
+    user_id UUID NOT NULL,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    text TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- one review per user per product
+CREATE UNIQUE INDEX idx_product_reviews_product_user ON product_reviews(product_id, user_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_product_reviews_product_created_at ON product_reviews(product_id, created_at, id) WHERE deleted_at IS NULL;
+
+-- ratings were only written by the seeder, they are recomputed from the reviews from now on
+ALTER TABLE products ALTER COLUMN rating DROP DEFAULT;
+ALTER TABLE products ALTER COLUMN rating TYPE NUMERIC(3,2) USING 0;
+ALTER TABLE products ALTER COLUMN rating SET DEFAULT 0;
+ALTER TABLE products ADD COLUMN review_count INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE shops ALTER COLUMN rating DROP DEFAULT;
+ALTER TABLE shops ALTER COLUMN rating TYPE NUMERIC(3,2) USING 0;
+ALTER TABLE shops ALTER COLUMN rating SET DEFAULT 0;
+ALTER TABLE shops ALTER COLUMN rating SET NOT NULL;
+ALTER TABLE shops ADD COLUMN review_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_products_available_stock;
+CREATE INDEX idx_products_total_stock ON products(total_stock);
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_stock_check;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS available_stock,
+    DROP COLUMN IF EXISTS reserved_stock;
+
+ALTER TABLE product_variants DROP COLUMN IF EXISTS reserved_stock;
+
+DROP TABLE IF EXISTS stock_reservation_items;
+DROP TABLE IF EXISTS stock_reservations;
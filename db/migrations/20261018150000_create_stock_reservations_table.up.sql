@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_ref VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'reserved' CHECK (status IN ('reserved', 'committed', 'released', 'expired')),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL
+);
+
+-- an order holds at most one live reservation, it can reserve again once released or expired
+CREATE UNIQUE INDEX idx_stock_reservations_order_ref ON stock_reservations(order_ref) WHERE status IN ('reserved', 'committed');
+CREATE INDEX idx_stock_reservations_expires_at ON stock_reservations(expires_at) WHERE status = 'reserved';
+
+CREATE TABLE IF NOT EXISTS stock_reservation_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    reservation_id UUID NOT NULL REFERENCES stock_reservations(id),
+    product_id UUID NOT NULL REFERENCES products(id),
+    variant_id UUID REFERENCES product_variants(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0)
+);
+
+CREATE INDEX idx_stock_reservation_items_reservation_id ON stock_reservation_items(reservation_id);
+
+-- reserved_stock is held by live reservations, available_stock is what can still be reserved:
+-- stock minus reserved_stock, summed across the variants like total_stock.
+ALTER TABLE product_variants
+    ADD COLUMN reserved_stock INTEGER NOT NULL DEFAULT 0 CHECK (reserved_stock >= 0);
+
+ALTER TABLE products
+    ADD COLUMN reserved_stock INTEGER NOT NULL DEFAULT 0 CHECK (reserved_stock >= 0),
+    ADD COLUMN available_stock INTEGER NOT NULL DEFAULT 0;
+
+UPDATE products SET available_stock = GREATEST(total_stock, 0);
+
+ALTER TABLE products ADD CONSTRAINT products_stock_check CHECK (stock >= 0) NOT VALID;
+
+DROP INDEX IF EXISTS idx_products_total_stock;
+CREATE INDEX idx_products_available_stock ON products(available_stock) WHERE deleted_at IS NULL;
@@ -0,0 +1,25 @@
+-- the ledger of every stock change, products.stock and product_variants.stock are the sum of its deltas
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id),
+    variant_id UUID REFERENCES product_variants(id),
+    delta INTEGER NOT NULL CHECK (delta <> 0),
+    balance INTEGER NOT NULL,
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('sale', 'restock', 'adjustment', 'return')),
+    actor_user_id UUID,
+    reference VARCHAR(100),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_stock_movements_product_id_created_at ON stock_movements(product_id, created_at, id);
+
+-- opening balances, so that the ledger adds up to the current stock
+INSERT INTO stock_movements (product_id, variant_id, delta, balance, reason, reference)
+SELECT id, NULL, stock, stock, 'adjustment', 'opening balance'
+FROM products
+WHERE stock <> 0;
+
+INSERT INTO stock_movements (product_id, variant_id, delta, balance, reason, reference)
+SELECT product_id, id, stock, stock, 'adjustment', 'opening balance'
+FROM product_variants
+WHERE stock <> 0;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS price_history;
+DROP TABLE IF EXISTS price_schedules;
+
+ALTER TABLE product_variants DROP COLUMN IF EXISTS sale_price;
+ALTER TABLE products DROP COLUMN IF EXISTS sale_price;
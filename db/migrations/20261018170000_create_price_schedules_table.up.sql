@@ -0,0 +1,45 @@
+-- the sale price set by an active price schedule, the effective price is COALESCE(sale_price, price)
+ALTER TABLE products ADD COLUMN sale_price DECIMAL(10,2);
+ALTER TABLE product_variants ADD COLUMN sale_price DECIMAL(10,2);
+
+CREATE TABLE IF NOT EXISTS price_schedules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id),
+    variant_id UUID REFERENCES product_variants(id),
+    sale_price DECIMAL(10,2) NOT NULL CHECK (sale_price > 0),
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled' CHECK (status IN ('scheduled', 'active', 'ended', 'cancelled')),
+    created_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_price_schedules_product_id_starts_at ON price_schedules(product_id, starts_at);
+CREATE INDEX idx_price_schedules_starts_at ON price_schedules(starts_at) WHERE status = 'scheduled';
+CREATE INDEX idx_price_schedules_ends_at ON price_schedules(ends_at) WHERE status = 'active';
+
+-- every price a product or variant had, written whenever its price or sale price changes.
+-- clock_timestamp keeps the order of several changes made in one transaction.
+CREATE TABLE IF NOT EXISTS price_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id),
+    variant_id UUID REFERENCES product_variants(id),
+    price DECIMAL(10,2) NOT NULL,
+    sale_price DECIMAL(10,2),
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('created', 'updated', 'sale_started', 'sale_ended')),
+    schedule_id UUID REFERENCES price_schedules(id),
+    actor_user_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT clock_timestamp()
+);
+
+CREATE INDEX idx_price_history_product_id_created_at ON price_history(product_id, created_at, id);
+
+INSERT INTO price_history (product_id, variant_id, price, reason, created_at)
+SELECT id, NULL, price, 'created', created_at
+FROM products;
+
+INSERT INTO price_history (product_id, variant_id, price, reason, created_at)
+SELECT product_id, id, price, 'created', created_at
+FROM product_variants;
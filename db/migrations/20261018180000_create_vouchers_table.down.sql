@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS voucher_categories;
+DROP TABLE IF EXISTS voucher_products;
+DROP TABLE IF EXISTS vouchers;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS vouchers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shop_id UUID NOT NULL REFERENCES shops(id),
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    discount_type VARCHAR(20) NOT NULL CHECK (discount_type IN ('percentage', 'fixed')),
+    discount_value DECIMAL(10,2) NOT NULL CHECK (discount_value > 0),
+    max_discount DECIMAL(10,2) CHECK (max_discount > 0),
+    min_spend DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (min_spend >= 0),
+    usage_limit INTEGER CHECK (usage_limit > 0),
+    usage_limit_per_user INTEGER CHECK (usage_limit_per_user > 0),
+    used_count INTEGER NOT NULL DEFAULT 0 CHECK (used_count >= 0),
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE UNIQUE INDEX idx_vouchers_shop_id_code ON vouchers(shop_id, code) WHERE deleted_at IS NULL;
+
+-- a voucher without eligible products nor categories applies to every product of its shop
+CREATE TABLE IF NOT EXISTS voucher_products (
+    voucher_id UUID NOT NULL REFERENCES vouchers(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id),
+    PRIMARY KEY (voucher_id, product_id)
+);
+
+CREATE TABLE IF NOT EXISTS voucher_categories (
+    voucher_id UUID NOT NULL REFERENCES vouchers(id) ON DELETE CASCADE,
+    category_id UUID NOT NULL REFERENCES categories(id),
+    PRIMARY KEY (voucher_id, category_id)
+);
@@ -0,0 +1,6 @@
+-- the existing products stay live, the new ones start as drafts until they are published
+ALTER TABLE products ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active'
+    CHECK (status IN ('draft', 'active', 'inactive', 'archived'));
+ALTER TABLE products ALTER COLUMN status SET DEFAULT 'draft';
+
+CREATE INDEX idx_products_status ON products(status) WHERE deleted_at IS NULL;
@@ -0,0 +1,3 @@
+-- the version is bumped by every edit of a product or shop and returned as its ETag
+ALTER TABLE products ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE shops ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
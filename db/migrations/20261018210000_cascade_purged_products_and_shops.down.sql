@@ -0,0 +1,62 @@
+DROP INDEX IF EXISTS idx_shops_deleted_at;
+DROP INDEX IF EXISTS idx_products_deleted_at;
+
+ALTER TABLE products
+    DROP CONSTRAINT products_shop_id_fkey,
+    ADD CONSTRAINT products_shop_id_fkey FOREIGN KEY (shop_id) REFERENCES shops(id);
+
+ALTER TABLE vouchers
+    DROP CONSTRAINT vouchers_shop_id_fkey,
+    ADD CONSTRAINT vouchers_shop_id_fkey FOREIGN KEY (shop_id) REFERENCES shops(id);
+
+ALTER TABLE product_variants
+    DROP CONSTRAINT product_variants_product_id_fkey,
+    ADD CONSTRAINT product_variants_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id);
+
+ALTER TABLE product_images
+    DROP CONSTRAINT product_images_product_id_fkey,
+    ADD CONSTRAINT product_images_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id);
+
+ALTER TABLE product_reviews
+    DROP CONSTRAINT product_reviews_product_id_fkey,
+    ADD CONSTRAINT product_reviews_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id);
+
+ALTER TABLE stock_reservation_items
+    DROP CONSTRAINT stock_reservation_items_product_id_fkey,
+    ADD CONSTRAINT stock_reservation_items_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id);
+
+ALTER TABLE stock_reservation_items
+    DROP CONSTRAINT stock_reservation_items_variant_id_fkey,
+    ADD CONSTRAINT stock_reservation_items_variant_id_fkey FOREIGN KEY (variant_id) REFERENCES product_variants(id);
+
+ALTER TABLE stock_movements
+    DROP CONSTRAINT stock_movements_product_id_fkey,
+    ADD CONSTRAINT stock_movements_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id);
+
+ALTER TABLE stock_movements
+    DROP CONSTRAINT stock_movements_variant_id_fkey,
+    ADD CONSTRAINT stock_movements_variant_id_fkey FOREIGN KEY (variant_id) REFERENCES product_variants(id);
+
+ALTER TABLE price_schedules
+    DROP CONSTRAINT price_schedules_product_id_fkey,
+    ADD CONSTRAINT price_schedules_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id);
+
+ALTER TABLE price_schedules
+    DROP CONSTRAINT price_schedules_variant_id_fkey,
+    ADD CONSTRAINT price_schedules_variant_id_fkey FOREIGN KEY (variant_id) REFERENCES product_variants(id);
+
+ALTER TABLE price_history
+    DROP CONSTRAINT price_history_product_id_fkey,
+    ADD CONSTRAINT price_history_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id);
+
+ALTER TABLE price_history
+    DROP CONSTRAINT price_history_variant_id_fkey,
+    ADD CONSTRAINT price_history_variant_id_fkey FOREIGN KEY (variant_id) REFERENCES product_variants(id);
+
+ALTER TABLE price_history
+    DROP CONSTRAINT price_history_schedule_id_fkey,
+    ADD CONSTRAINT price_history_schedule_id_fkey FOREIGN KEY (schedule_id) REFERENCES price_schedules(id);
+
+ALTER TABLE voucher_products
+    DROP CONSTRAINT voucher_products_product_id_fkey,
+    ADD CONSTRAINT voucher_products_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id);
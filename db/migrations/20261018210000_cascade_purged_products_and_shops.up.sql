@@ -0,0 +1,70 @@
+-- soft deleted products and shops are kept in the trash until they are restored or purged.
+-- purging deletes the row for good, with everything that belongs to it.
+ALTER TABLE products
+    DROP CONSTRAINT products_shop_id_fkey,
+    ADD CONSTRAINT products_shop_id_fkey FOREIGN KEY (shop_id) REFERENCES shops(id) ON DELETE CASCADE;
+
+ALTER TABLE vouchers
+    DROP CONSTRAINT vouchers_shop_id_fkey,
+    ADD CONSTRAINT vouchers_shop_id_fkey FOREIGN KEY (shop_id) REFERENCES shops(id) ON DELETE CASCADE;
+
+ALTER TABLE product_variants
+    DROP CONSTRAINT product_variants_product_id_fkey,
+    ADD CONSTRAINT product_variants_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE;
+
+ALTER TABLE product_images
+    DROP CONSTRAINT product_images_product_id_fkey,
+    ADD CONSTRAINT product_images_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE;
+
+ALTER TABLE product_reviews
+    DROP CONSTRAINT product_reviews_product_id_fkey,
+    ADD CONSTRAINT product_reviews_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE;
+
+ALTER TABLE stock_reservation_items
+    DROP CONSTRAINT stock_reservation_items_product_id_fkey,
+    ADD CONSTRAINT stock_reservation_items_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE;
+
+ALTER TABLE stock_reservation_items
+    DROP CONSTRAINT stock_reservation_items_variant_id_fkey,
+    ADD CONSTRAINT stock_reservation_items_variant_id_fkey FOREIGN KEY (variant_id) REFERENCES product_variants(id) ON DELETE CASCADE;
+
+ALTER TABLE stock_movements
+    DROP CONSTRAINT stock_movements_product_id_fkey,
+    ADD CONSTRAINT stock_movements_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE;
+
+ALTER TABLE stock_movements
+    DROP CONSTRAINT stock_movements_variant_id_fkey,
+    ADD CONSTRAINT stock_movements_variant_id_fkey FOREIGN KEY (variant_id) REFERENCES product_variants(id) ON DELETE CASCADE;
+
+ALTER TABLE price_schedules
+    DROP CONSTRAINT price_schedules_product_id_fkey,
+    ADD CONSTRAINT price_schedules_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE;
+
+ALTER TABLE price_schedules
+    DROP CONSTRAINT price_schedules_variant_id_fkey,
+    ADD CONSTRAINT price_schedules_variant_id_fkey FOREIGN KEY (variant_id) REFERENCES product_variants(id) ON DELETE CASCADE;
+
+ALTER TABLE price_history
+    DROP CONSTRAINT price_history_product_id_fkey,
+    ADD CONSTRAINT price_history_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE;
+
+ALTER TABLE price_history
+    DROP CONSTRAINT price_history_variant_id_fkey,
+    ADD CONSTRAINT price_history_variant_id_fkey FOREIGN KEY (variant_id) REFERENCES product_variants(id) ON DELETE CASCADE;
+
+ALTER TABLE price_history
+    DROP CONSTRAINT price_history_schedule_id_fkey,
+    ADD CONSTRAINT price_history_schedule_id_fkey FOREIGN KEY (schedule_id) REFERENCES price_schedules(id) ON DELETE CASCADE;
+
+ALTER TABLE voucher_products
+    DROP CONSTRAINT voucher_products_product_id_fkey,
+    ADD CONSTRAINT voucher_products_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE;
+
+-- deleting a shop now also moves its products to the trash, with the same deleted_at
+UPDATE products p
+SET deleted_at = s.deleted_at
+FROM shops s
+WHERE p.shop_id = s.id AND s.deleted_at IS NOT NULL AND p.deleted_at IS NULL;
+
+CREATE INDEX idx_products_deleted_at ON products(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_shops_deleted_at ON shops(deleted_at) WHERE deleted_at IS NOT NULL;
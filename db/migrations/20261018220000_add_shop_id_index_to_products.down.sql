@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_products_shop_id_created_at;
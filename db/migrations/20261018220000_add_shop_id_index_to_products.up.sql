@@ -0,0 +1,2 @@
+-- the products of a shop are listed on its page
+CREATE INDEX idx_products_shop_id_created_at ON products(shop_id, created_at, id) WHERE deleted_at IS NULL;
@@ -0,0 +1,18 @@
+-- domain events written in the transaction of the write that raised them, the relay publishes them
+-- in id order. the events of an aggregate are written after its row is locked, so their ids follow
+-- the order of the writes.
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    aggregate_type VARCHAR(20) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    published_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_outbox_events_pending ON outbox_events(id) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_events_published_at ON outbox_events(published_at) WHERE published_at IS NOT NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE products DROP COLUMN IF EXISTS answered_count;
+ALTER TABLE products DROP COLUMN IF EXISTS question_count;
+
+DROP TABLE IF EXISTS product_questions;
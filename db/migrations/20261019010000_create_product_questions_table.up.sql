@@ -0,0 +1,20 @@
+-- questions of the buyers on a product, answered by its shop. answered questions are public.
+CREATE TABLE IF NOT EXISTS product_questions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    question TEXT NOT NULL,
+    answer TEXT,
+    answered_by UUID,
+    answered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_product_questions_answered ON product_questions(product_id, answered_at, id) WHERE answer IS NOT NULL AND deleted_at IS NULL;
+CREATE INDEX idx_product_questions_unanswered ON product_questions(product_id, created_at, id) WHERE answer IS NULL AND deleted_at IS NULL;
+
+-- recomputed from the questions on every write, like the review count
+ALTER TABLE products ADD COLUMN question_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN answered_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+ALTER TABLE products DROP COLUMN IF EXISTS favorite_count;
+
+DROP TABLE IF EXISTS product_favorites;
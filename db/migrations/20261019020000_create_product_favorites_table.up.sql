@@ -0,0 +1,13 @@
+-- the wishlist of the users, the latest favorites first
+CREATE TABLE IF NOT EXISTS product_favorites (
+    user_id UUID NOT NULL,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    PRIMARY KEY (user_id, product_id)
+);
+
+CREATE INDEX idx_product_favorites_user_created_at ON product_favorites(user_id, created_at, product_id);
+CREATE INDEX idx_product_favorites_product_id ON product_favorites(product_id);
+
+-- recomputed from the favorites on every write, like the review count
+ALTER TABLE products ADD COLUMN favorite_count INTEGER NOT NULL DEFAULT 0;
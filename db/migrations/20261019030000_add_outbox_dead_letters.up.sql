@@ -0,0 +1,7 @@
+-- an event failing OUTBOX_MAX_ATTEMPTS times is a dead letter, the relay skips it and stops holding
+-- back the next events of its aggregate. dead letters are kept for inspection, they are never purged.
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS dead_at TIMESTAMP WITH TIME ZONE;
+
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+CREATE INDEX idx_outbox_events_pending ON outbox_events(id) WHERE published_at IS NULL AND dead_at IS NULL;
+CREATE INDEX idx_outbox_events_dead_at ON outbox_events(dead_at) WHERE dead_at IS NOT NULL;
@@ -0,0 +1,3 @@
+-- the user who reserved the stock, the only one who can read, commit or release the reservation.
+-- the reservations made before are left without owner and only expire.
+ALTER TABLE stock_reservations ADD COLUMN IF NOT EXISTS user_id UUID;
@@ -8,7 +8,9 @@ import (
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/google/uuid"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/slug"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/rs/zerolog/log"
 )
 
@@ -32,6 +34,12 @@ func Execute(db *sqlx.DB, table string, total int) {
 // Run seeds.
 func (s *Seed) run(table string, total int) {
 	switch table {
+	case "categories":
+		s.categoriesSeed()
+	case "brands":
+		s.brandsSeed(total)
+	case "reviews":
+		s.reviewsSeed(total)
 	case "products":
 		s.productsSeed(total)
 	case "delete-all":
@@ -69,6 +77,70 @@ func (s *Seed) deleteAll() {
 		}
 	}()
 
+	// voucher_products and voucher_categories are deleted with their vouchers
+	_, err = tx.Exec(`DELETE FROM vouchers`)
+	if err != nil {
+		log.Error().Err(err).Msg("Error deleting vouchers")
+		return
+	}
+	log.Info().Msg("vouchers table deleted successfully")
+
+	_, err = tx.Exec(`DELETE FROM product_images`)
+	if err != nil {
+		log.Error().Err(err).Msg("Error deleting product images")
+		return
+	}
+	log.Info().Msg("product_images table deleted successfully")
+
+	_, err = tx.Exec(`DELETE FROM price_history`)
+	if err != nil {
+		log.Error().Err(err).Msg("Error deleting price history")
+		return
+	}
+	log.Info().Msg("price_history table deleted successfully")
+
+	_, err = tx.Exec(`DELETE FROM price_schedules`)
+	if err != nil {
+		log.Error().Err(err).Msg("Error deleting price schedules")
+		return
+	}
+	log.Info().Msg("price_schedules table deleted successfully")
+
+	_, err = tx.Exec(`DELETE FROM stock_movements`)
+	if err != nil {
+		log.Error().Err(err).Msg("Error deleting stock movements")
+		return
+	}
+	log.Info().Msg("stock_movements table deleted successfully")
+
+	_, err = tx.Exec(`DELETE FROM stock_reservation_items`)
+	if err != nil {
+		log.Error().Err(err).Msg("Error deleting stock reservation items")
+		return
+	}
+	log.Info().Msg("stock_reservation_items table deleted successfully")
+
+	_, err = tx.Exec(`DELETE FROM stock_reservations`)
+	if err != nil {
+		log.Error().Err(err).Msg("Error deleting stock reservations")
+		return
+	}
+	log.Info().Msg("stock_reservations table deleted successfully")
+
+	_, err = tx.Exec(`DELETE FROM product_reviews`)
+	if err != nil {
+		log.Error().Err(err).Msg("Error deleting product reviews")
+		return
+	}
+	log.Info().Msg("product_reviews table deleted successfully")
+
+	_, err = tx.Exec(`DELETE FROM product_variants`)
+	if err != nil {
+		log.Error().Err(err).Msg("Error deleting product variants")
+		return
+	}
+	log.Info().Msg("product_variants table deleted successfully")
+
 	_, err = tx.Exec(`DELETE FROM products`)
 	if err != nil {
 		log.Error().Err(err).Msg("Error deleting products")
@@ -76,9 +148,138 @@ func (s *Seed) deleteAll() {
 	}
 	log.Info().Msg("products table deleted successfully")
 
+	_, err = tx.Exec(`DELETE FROM categories`)
+	if err != nil {
+		log.Error().Err(err).Msg("Error deleting categories")
+		return
+	}
+	log.Info().Msg("categories table deleted successfully")
+
+	_, err = tx.Exec(`DELETE FROM brands`)
+	if err != nil {
+		log.Error().Err(err).Msg("Error deleting brands")
+		return
+	}
+	log.Info().Msg("brands table deleted successfully")
+
 	log.Info().Msg("=== All tables deleted successfully ===")
 }
 
+// productStatuses are picked at random for the seeded products, most of them are active.
+var productStatuses = []string{"active", "active", "active", "active", "draft", "inactive", "archived"}
+
+// categoryTree is the taxonomy created by the categories seed, root names map to their children.
+var categoryTree = map[string][]string{
+	"Electronics": {"Phones", "Laptops", "Audio"},
+	"Fashion":     {"Men's Clothing", "Women's Clothing", "Shoes"},
+	"Home":        {"Kitchen", "Furniture"},
+	"Sports":      {"Outdoor", "Fitness"},
+}
+
+func (s *Seed) categoriesSeed() {
+	tx, err := s.db.BeginTxx(context.Background(), nil)
+	if err != nil {
+		log.Error().Err(err).Msg("Error starting transaction")
+		return
+	}
+	defer func() {
+		if err != nil {
+			err = tx.Rollback()
+			log.Error().Err(err).Msg("Error rolling back transaction")
+			return
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			log.Error().Err(err).Msg("Error committing transaction")
+		}
+	}()
+
+	categoryMaps := make([]map[string]any, 0)
+
+	for root, children := range categoryTree {
+		rootId := uuid.New().String()
+		categoryMaps = append(categoryMaps, map[string]any{
+			"id":        rootId,
+			"parent_id": nil,
+			"name":      root,
+			"slug":      slug.Make(root),
+		})
+
+		for _, child := range children {
+			categoryMaps = append(categoryMaps, map[string]any{
+				"id":        uuid.New().String(),
+				"parent_id": rootId,
+				"name":      child,
+				"slug":      slug.Make(child),
+			})
+		}
+	}
+
+	_, err = tx.NamedExec(`
+		INSERT INTO categories (id, parent_id, name, slug)
+		VALUES (:id, :parent_id, :name, :slug)
+	`, categoryMaps)
+	if err != nil {
+		log.Error().Err(err).Msg("Error creating categories")
+		return
+	}
+
+	log.Info().Msg("categories table seeded successfully")
+}
+
+func (s *Seed) brandsSeed(total int) {
+	tx, err := s.db.BeginTxx(context.Background(), nil)
+	if err != nil {
+		log.Error().Err(err).Msg("Error starting transaction")
+		return
+	}
+	defer func() {
+		if err != nil {
+			err = tx.Rollback()
+			log.Error().Err(err).Msg("Error rolling back transaction")
+			return
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			log.Error().Err(err).Msg("Error committing transaction")
+		}
+	}()
+
+	var (
+		brandMaps = make([]map[string]any, 0, total)
+		slugs     = make(map[string]bool, total)
+	)
+
+	for len(brandMaps) < total {
+		name := gofakeit.Company()
+
+		// fake company names repeat, brand slugs must be unique
+		if slugs[slug.Make(name)] {
+			continue
+		}
+		slugs[slug.Make(name)] = true
+
+		brandMaps = append(brandMaps, map[string]any{
+			"id":   uuid.New().String(),
+			"name": name,
+			"slug": slug.Make(name),
+		})
+	}
+
+	_, err = tx.NamedExec(`
+		INSERT INTO brands (id, name, slug)
+		VALUES (:id, :name, :slug)
+	`, brandMaps)
+	if err != nil {
+		log.Error().Err(err).Msg("Error creating brands")
+		return
+	}
+
+	log.Info().Msg("brands table seeded successfully")
+}
+
 func (s *Seed) productsSeed(total int) {
 	tx, err := s.db.BeginTxx(context.Background(), nil)
 	if err != nil {
@@ -114,36 +315,203 @@ func (s *Seed) productsSeed(total int) {
 		return
 	}
 
-	productMaps := make([]map[string]any, 0, total)
+	type Category struct {
+		ID   string `db:"id"`
+		Name string `db:"name"`
+	}
+
+	var categories []Category
+	err = s.db.Select(&categories, `SELECT id, name FROM categories WHERE deleted_at IS NULL`)
+	if err != nil {
+		log.Error().Err(err).Msg("Error selecting categories")
+		return
+	}
+
+	if len(categories) == 0 {
+		log.Warn().Msg("No categories found. Run the categories seed first. Products seeding aborted.")
+		return
+	}
+
+	type Brand struct {
+		ID   *string `db:"id"`
+		Name *string `db:"name"`
+	}
+
+	var brands []Brand
+	err = s.db.Select(&brands, `SELECT id, name FROM brands WHERE deleted_at IS NULL`)
+	if err != nil {
+		log.Error().Err(err).Msg("Error selecting brands")
+		return
+	}
+	// products without brand are seeded too
+	brands = append(brands, Brand{})
+
+	var (
+		productMaps = make([]map[string]any, 0, total)
+		ids         = make([]string, 0, total)
+	)
 
 	for i := 0; i < total; i++ {
 		selectedShop := shops[rand.Intn(len(shops))]
+		selectedCategory := categories[rand.Intn(len(categories))]
+		selectedBrand := brands[rand.Intn(len(brands))]
+
+		var (
+			price = gofakeit.Price(1, 1000)
+			stock = gofakeit.Number(0, 100)
+		)
+
+		id := uuid.New().String()
+		ids = append(ids, id)
 
 		dataProductToInsert := map[string]any{
-			"id":          uuid.New().String(),
-			"shop_id":     selectedShop.ID,
-			"name":        gofakeit.ProductName(),
-			"description": gofakeit.Paragraph(1, 3, 10, " "),
-			"category":    gofakeit.Word(),
-			"price":       gofakeit.Price(1, 1000),
-			"stock":       gofakeit.Number(0, 100),
-			"rating":      gofakeit.Number(1, 5),
-			"brand":       gofakeit.Company(),
-			"created_at":  gofakeit.Date(),
-			"updated_at":  gofakeit.Date(),
+			"id":              id,
+			"shop_id":         selectedShop.ID,
+			"name":            gofakeit.ProductName(),
+			"description":     gofakeit.Paragraph(1, 3, 10, " "),
+			"category_id":     selectedCategory.ID,
+			"category":        selectedCategory.Name,
+			"price":           price,
+			"stock":           stock,
+			"min_price":       price,
+			"max_price":       price,
+			"total_stock":     stock,
+			"available_stock": stock,
+			"brand_id":        selectedBrand.ID,
+			"brand":           selectedBrand.Name,
+			"status":          productStatuses[rand.Intn(len(productStatuses))],
+			"created_at":      gofakeit.Date(),
+			"updated_at":      gofakeit.Date(),
 		}
 
 		productMaps = append(productMaps, dataProductToInsert)
 	}
 
 	_, err = tx.NamedExec(`
-		INSERT INTO products (id, shop_id, name, description, category, price, stock, rating, brand, created_at, updated_at)
-		VALUES (:id, :shop_id, :name, :description, :category, :price, :stock, :rating, :brand, :created_at, :updated_at)
+		INSERT INTO products (id, shop_id, name, description, category_id, category, price, stock, min_price, max_price, total_stock, available_stock, brand_id, brand, status, created_at, updated_at)
+		VALUES (:id, :shop_id, :name, :description, :category_id, :category, :price, :stock, :min_price, :max_price, :total_stock, :available_stock, :brand_id, :brand, :status, :created_at, :updated_at)
 	`, productMaps)
 	if err != nil {
 		log.Error().Err(err).Msg("Error creating products")
 		return
 	}
 
+	// the ledger opens with the seeded stock, as the migration does for existing products
+	_, err = tx.Exec(`
+		INSERT INTO stock_movements (product_id, delta, balance, reason, reference)
+		SELECT id, stock, stock, 'restock', 'initial stock'
+		FROM products
+		WHERE stock > 0 AND id = ANY($1::uuid[])
+	`, pq.Array(ids))
+	if err != nil {
+		log.Error().Err(err).Msg("Error creating stock movements")
+		return
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO price_history (product_id, price, reason, created_at)
+		SELECT id, price, 'created', created_at
+		FROM products
+		WHERE id = ANY($1::uuid[])
+	`, pq.Array(ids))
+	if err != nil {
+		log.Error().Err(err).Msg("Error creating price history")
+		return
+	}
+
 	log.Info().Msg("products table seeded successfully")
 }
+
+func (s *Seed) reviewsSeed(total int) {
+	tx, err := s.db.BeginTxx(context.Background(), nil)
+	if err != nil {
+		log.Error().Err(err).Msg("Error starting transaction")
+		return
+	}
+	defer func() {
+		if err != nil {
+			err = tx.Rollback()
+			log.Error().Err(err).Msg("Error rolling back transaction")
+			return
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			log.Error().Err(err).Msg("Error committing transaction")
+		}
+	}()
+
+	var productIds []string
+	err = s.db.Select(&productIds, `SELECT id FROM products WHERE deleted_at IS NULL`)
+	if err != nil {
+		log.Error().Err(err).Msg("Error selecting products")
+		return
+	}
+
+	if len(productIds) == 0 {
+		log.Warn().Msg("No products found. Reviews seeding aborted.")
+		return
+	}
+
+	reviewMaps := make([]map[string]any, 0, total)
+
+	// every review has its own user, so the one review per user per product rule always holds
+	for i := 0; i < total; i++ {
+		reviewMaps = append(reviewMaps, map[string]any{
+			"id":         uuid.New().String(),
+			"product_id": productIds[rand.Intn(len(productIds))],
+			"user_id":    uuid.New().String(),
+			"rating":     gofakeit.Number(1, 5),
+			"text":       gofakeit.Sentence(12),
+			"created_at": gofakeit.Date(),
+		})
+	}
+
+	_, err = tx.NamedExec(`
+		INSERT INTO product_reviews (id, product_id, user_id, rating, text, created_at, updated_at)
+		VALUES (:id, :product_id, :user_id, :rating, :text, :created_at, :created_at)
+	`, reviewMaps)
+	if err != nil {
+		log.Error().Err(err).Msg("Error creating reviews")
+		return
+	}
+
+	_, err = tx.Exec(`
+		UPDATE products p
+		SET
+			rating = r.rating,
+			review_count = r.review_count
+		FROM (
+			SELECT product_id, ROUND(AVG(rating), 2) as rating, COUNT(id) as review_count
+			FROM product_reviews
+			WHERE deleted_at IS NULL
+			GROUP BY product_id
+		) r
+		WHERE p.id = r.product_id
+	`)
+	if err != nil {
+		log.Error().Err(err).Msg("Error updating product ratings")
+		return
+	}
+
+	_, err = tx.Exec(`
+		UPDATE shops s
+		SET
+			rating = r.rating,
+			review_count = r.review_count
+		FROM (
+			SELECT p.shop_id, ROUND(AVG(pr.rating), 2) as rating, COUNT(pr.id) as review_count
+			FROM product_reviews pr
+			JOIN products p ON p.id = pr.product_id
+			WHERE p.deleted_at IS NULL AND pr.deleted_at IS NULL
+			GROUP BY p.shop_id
+		) r
+		WHERE s.id = r.shop_id
+	`)
+	if err != nil {
+		log.Error().Err(err).Msg("Error updating shop ratings")
+		return
+	}
+
+	log.Info().Msg("product_reviews table seeded successfully")
+}
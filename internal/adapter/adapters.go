@@ -7,6 +7,8 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/publisher"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/scheduler"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
 )
@@ -25,11 +27,13 @@ type Adapter struct {
 	// Driving Adapters
 	RestServer *fiber.App
 	WsServer   *http.Server
+	Scheduler  *scheduler.Scheduler
 
 	//Driven Adapters
 	ShopeefunPostgres *sqlx.DB
 	Validator         Validator // *validator.Validator
 	ShopeefunStorage  *s3.Client
+	Publisher         publisher.Publisher
 }
 
 func (a *Adapter) Sync(opts ...Option) {
@@ -56,6 +60,20 @@ func (a *Adapter) Unsync() error {
 		log.Info().Msg("Ws server disconnected")
 	}
 
+	// jobs use the database, they are stopped before it is closed
+	if a.Scheduler != nil {
+		a.Scheduler.Stop()
+		log.Info().Msg("Scheduler stopped")
+	}
+
+	// the outbox relay job publishes, the publisher is closed once the scheduler stopped
+	if a.Publisher != nil {
+		if err := a.Publisher.Close(); err != nil {
+			errs = append(errs, err.Error())
+		}
+		log.Info().Msg("Publisher disconnected")
+	}
+
 	if a.ShopeefunPostgres != nil {
 		if err := a.ShopeefunPostgres.Close(); err != nil {
 			errs = append(errs, err.Error())
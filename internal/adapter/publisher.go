@@ -0,0 +1,33 @@
+package adapter
+
+import (
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/infrastructure/config"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/publisher"
+	"github.com/rs/zerolog/log"
+)
+
+// WithPublisher sets up the publisher of the outbox events, chosen with OUTBOX_DRIVER.
+//
+// The nats driver publishes to JetStream, the subjects "<OUTBOX_SUBJECT_PREFIX>.>" must be bound to a stream.
+func WithPublisher() Option {
+	return func(a *Adapter) {
+		cfg := config.Envs.Outbox
+
+		switch cfg.Driver {
+		case "nats":
+			p, err := publisher.NewNatsPublisher(cfg.NatsURL, config.Envs.App.Name, time.Duration(cfg.Timeout)*time.Second)
+			if err != nil {
+				log.Fatal().Err(err).Msg("Error while creating the nats publisher")
+			}
+			a.Publisher = p
+		case "memory":
+			a.Publisher = publisher.NewMemoryPublisher()
+		default:
+			a.Publisher = publisher.NewLogPublisher()
+		}
+
+		log.Info().Str("driver", cfg.Driver).Msg("Publisher connected")
+	}
+}
@@ -0,0 +1,13 @@
+package adapter
+
+import (
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/scheduler"
+	"github.com/rs/zerolog/log"
+)
+
+func WithScheduler(s *scheduler.Scheduler) Option {
+	log.Info().Msg("Scheduler connected")
+	return func(a *Adapter) {
+		a.Scheduler = s
+	}
+}
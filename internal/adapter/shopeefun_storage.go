@@ -0,0 +1,45 @@
+package adapter
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/infrastructure/config"
+	"github.com/rs/zerolog/log"
+)
+
+// WithShopeefunStorage sets up an S3 compatible client (AWS S3, DigitalOcean Spaces, MinIO).
+//
+// Set SHOPEEFUN_STORAGE_PATH_STYLE=true when the storage does not support virtual hosted buckets (ex: MinIO).
+func WithShopeefunStorage() Option {
+	return func(a *Adapter) {
+		var (
+			cfg      = config.Envs.ShopeefunStorage
+			endpoint = cfg.Endpoint
+		)
+
+		if endpoint == "" {
+			log.Warn().Msg("Shopeefun Storage endpoint is not set, storage is disabled")
+			return
+		}
+
+		if !strings.Contains(endpoint, "://") {
+			endpoint = "https://" + endpoint
+		}
+
+		a.ShopeefunStorage = s3.New(s3.Options{
+			Region:       cfg.Region,
+			BaseEndpoint: aws.String(endpoint),
+			UsePathStyle: cfg.UsePathStyle,
+			Credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+				return aws.Credentials{
+					AccessKeyID:     cfg.Key,
+					SecretAccessKey: cfg.Secret,
+				}, nil
+			}),
+		})
+		log.Info().Msg("Shopeefun Storage connected")
+	}
+}
@@ -25,6 +25,7 @@ type Config struct {
 		LogFileWs               string `env:"APP_LOG_FILE_WS" env-default:"./logs/ws.log"`
 		LocalStoragePublicPath  string `env:"LOCAL_STORAGE_PUBLIC_PATH" env-default:"./storage/public"`
 		LocalStoragePrivatePath string `env:"LOCAL_STORAGE_PRIVATE_PATH" env-default:"./storage/private"`
+		BodyLimit               int    `env:"APP_BODY_LIMIT" env-default:"20" env-description:"max request body size in megabytes"`
 	}
 	DB struct {
 		ConnectionTimeout int `env:"DB_CONN_TIMEOUT" env-default:"30" env-description:"database timeout in seconds"`
@@ -32,6 +33,24 @@ type Config struct {
 		MaxIdleCons       int `env:"DB_MAX_IdLE_CONS" env-default:"20" env-description:"database max idle conn in seconds"`
 		ConnMaxLifetime   int `env:"DB_CONN_MAX_LIFETIME" env-default:"0" env-description:"database conn max lifetime in seconds"`
 	}
+	Trash struct {
+		RetentionDays int `env:"TRASH_RETENTION_DAYS" env-default:"30" env-description:"days a deleted product or shop stays in the trash before it is purged"`
+	}
+	Outbox struct {
+		Driver        string `env:"OUTBOX_DRIVER" env-default:"log" env-description:"publisher of the outbox events: log, memory or nats"`
+		NatsURL       string `env:"OUTBOX_NATS_URL" env-default:"nats://localhost:4222"`
+		SubjectPrefix string `env:"OUTBOX_SUBJECT_PREFIX" env-default:"shopeefun" env-description:"events are published to <prefix>.<event type>"`
+		Timeout       int    `env:"OUTBOX_TIMEOUT" env-default:"5" env-description:"publish timeout in seconds"`
+		MaxAttempts   int    `env:"OUTBOX_MAX_ATTEMPTS" env-default:"10" env-description:"failed publications of an event before it is moved to the dead letters"`
+		RetentionDays int    `env:"OUTBOX_RETENTION_DAYS" env-default:"7" env-description:"days a published event is kept in the outbox"`
+	}
+	Webhook struct {
+		Timeout              int  `env:"WEBHOOK_TIMEOUT" env-default:"10" env-description:"delivery timeout in seconds"`
+		MaxAttempts          int  `env:"WEBHOOK_MAX_ATTEMPTS" env-default:"8" env-description:"attempts of a delivery before it is failed"`
+		DisableAfter         int  `env:"WEBHOOK_DISABLE_AFTER" env-default:"20" env-description:"consecutive failed attempts before a webhook is disabled"`
+		RetentionDays        int  `env:"WEBHOOK_RETENTION_DAYS" env-default:"30" env-description:"days a finished delivery is kept in the log"`
+		AllowPrivateNetworks bool `env:"WEBHOOK_ALLOW_PRIVATE_NETWORKS" env-default:"false" env-description:"allow urls resolving to private addresses, for local development"`
+	}
 	Guard struct {
 		JwtPrivateKey   string `env:"JWT_PRIVATE_KEY"`
 		JwtPrivateKeyWs string `env:"JWT_PRIVATE_KEY_WS"`
@@ -46,11 +65,12 @@ type Config struct {
 		SslMode  string `env:"SHOPEEFUN_POSTGRES_SSL_MODE" env-default:"disable"`
 	}
 	ShopeefunStorage struct {
-		Key      string `env:"SHOPEEFUN_STORAGE_KEY"`
-		Secret   string `env:"SHOPEEFUN_STORAGE_SECRET"`
-		Endpoint string `env:"SHOPEEFUN_STORAGE_ENDPOINT"`
-		Region   string `env:"SHOPEEFUN_STORAGE_REGION"`
-		Bucket   string `env:"SHOPEEFUN_STORAGE_BUCKET"`
+		Key          string `env:"SHOPEEFUN_STORAGE_KEY"`
+		Secret       string `env:"SHOPEEFUN_STORAGE_SECRET"`
+		Endpoint     string `env:"SHOPEEFUN_STORAGE_ENDPOINT"`
+		Region       string `env:"SHOPEEFUN_STORAGE_REGION"`
+		Bucket       string `env:"SHOPEEFUN_STORAGE_BUCKET"`
+		UsePathStyle bool   `env:"SHOPEEFUN_STORAGE_PATH_STYLE" env-default:"false"`
 	}
 	Oauth struct {
 		Google struct {
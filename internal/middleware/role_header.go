@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+const RoleAdmin = "admin"
+
+// AdminOnly must run after UserIdHeader, the gateway forwards the role of the caller in X-USER-ROLE.
+func AdminOnly(c *fiber.Ctx) error {
+	if GetLocals(c).GetRole() != RoleAdmin {
+		log.Warn().Msg("middleware::AdminOnly - Forbidden [Role is not admin]")
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Forbidden",
+			"success": false,
+		})
+	}
+
+	return c.Next()
+}
@@ -19,6 +19,10 @@ func GetLocals(c *fiber.Ctx) *Locals {
 		log.Warn().Msg("middleware::Locals-GetLocals failed to get user_id from locals")
 	}
 
+	if role, ok := c.Locals("role").(string); ok {
+		l.Role = role
+	}
+
 	return &l
 }
 
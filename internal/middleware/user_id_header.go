@@ -18,6 +18,15 @@ func UserIdHeader(c *fiber.Ctx) error {
 	}
 
 	c.Locals("user_id", userId)
+	c.Locals("role", c.Get("X-USER-ROLE"))
+
+	return c.Next()
+}
+
+// OptionalUserIdHeader is UserIdHeader for public routes, the user is only known when the header is set.
+func OptionalUserIdHeader(c *fiber.Ctx) error {
+	c.Locals("user_id", c.Get("X-USER-ID"))
+	c.Locals("role", c.Get("X-USER-ROLE"))
 
 	return c.Next()
 }
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"errors"
+	"mime/multipart"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/types"
+)
+
+var ErrBrandInUse = errors.New("brand is still used by products")
+
+type CreateBrandRequest struct {
+	Name string `json:"name" validate:"required,max=100" db:"name"`
+	Slug string `json:"slug" validate:"omitempty,max=120,slug" db:"slug"` // made from the name when empty
+}
+
+type CreateBrandResponse struct {
+	Id   string `json:"id" db:"id"`
+	Slug string `json:"slug" db:"slug"`
+}
+
+type GetBrandRequest struct {
+	Id string `params:"id" validate:"uuid" db:"id"`
+}
+
+type BrandItem struct {
+	Id      string `json:"id" db:"id"`
+	Name    string `json:"name" db:"name"`
+	Slug    string `json:"slug" db:"slug"`
+	LogoUrl string `json:"logo_url" db:"-"`
+
+	LogoFilename *string `json:"-" db:"logo_filename"`
+}
+
+type BrandsRequest struct {
+	Page     int    `query:"page" validate:"required,min=1"`
+	Paginate int    `query:"paginate" validate:"required,min=1,max=100"`
+	Q        string `query:"q" validate:"omitempty,max=100"` // prefix of the brand name
+}
+
+func (r *BrandsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type BrandsResponse struct {
+	Items []BrandItem `json:"items"`
+	Meta  types.Meta  `json:"meta"`
+}
+
+type UpdateBrandRequest struct {
+	Id   string `params:"id" validate:"uuid" db:"id"`
+	Name string `json:"name" validate:"required,max=100" db:"name"`
+	Slug string `json:"slug" validate:"omitempty,max=120,slug" db:"slug"`
+}
+
+type UpdateBrandResponse struct {
+	Id   string `json:"id" db:"id"`
+	Slug string `json:"slug" db:"slug"`
+}
+
+type UploadLogoRequest struct {
+	Id string `params:"id" validate:"uuid" db:"id"`
+
+	Logo *multipart.FileHeader `form:"logo" validate:"required"`
+}
+
+type DeleteBrandRequest struct {
+	Id string `params:"id" validate:"uuid" db:"id"`
+}
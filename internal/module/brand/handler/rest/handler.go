@@ -0,0 +1,207 @@
+package rest
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/adapter"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/infrastructure/config"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/middleware"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/brand/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/brand/ports"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/brand/repository"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/brand/service"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/response"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/storage_manager"
+	"github.com/rs/zerolog/log"
+)
+
+type brandHandler struct {
+	service ports.BrandService
+}
+
+func NewBrandHandler() *brandHandler {
+	var (
+		handler = new(brandHandler)
+		repo    = repository.NewBrandRepository(adapter.Adapters.ShopeefunPostgres)
+		storage = storage_manager.NewS3Storage(adapter.Adapters.ShopeefunStorage, config.Envs.ShopeefunStorage.Bucket)
+		service = service.NewBrandService(repo, storage)
+	)
+	handler.service = service
+
+	return handler
+}
+
+func (h *brandHandler) Register(router fiber.Router) {
+	router.Get("/brands", h.GetBrands)
+	router.Get("/brands/:id", h.GetBrand)
+	router.Post("/brands", middleware.UserIdHeader, middleware.AdminOnly, h.CreateBrand)
+	router.Patch("/brands/:id", middleware.UserIdHeader, middleware.AdminOnly, h.UpdateBrand)
+	router.Put("/brands/:id/logo", middleware.UserIdHeader, middleware.AdminOnly, h.UploadLogo)
+	router.Delete("/brands/:id", middleware.UserIdHeader, middleware.AdminOnly, h.DeleteBrand)
+}
+
+func (h *brandHandler) CreateBrand(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.CreateBrandRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::CreateBrand - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::CreateBrand - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.CreateBrand(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, ""))
+}
+
+func (h *brandHandler) GetBrand(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.GetBrandRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	req.Id = c.Params("id")
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetBrand - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetBrand(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *brandHandler) GetBrands(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.BrandsRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetBrands - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.SetDefault()
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetBrands - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetBrands(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *brandHandler) UpdateBrand(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.UpdateBrandRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateBrand - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.Id = c.Params("id")
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::UpdateBrand - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.UpdateBrand(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *brandHandler) UploadLogo(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.UploadLogoRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::UploadLogo - Parse multipart form")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.Id = c.Params("id")
+	if files := form.File["logo"]; len(files) > 0 {
+		req.Logo = files[0]
+	}
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Str("id", req.Id).Msg("handler::UploadLogo - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.UploadLogo(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *brandHandler) DeleteBrand(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.DeleteBrandRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	req.Id = c.Params("id")
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteBrand - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.DeleteBrand(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+	"io"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/brand/entity"
+)
+
+type BrandRepository interface {
+	CreateBrand(ctx context.Context, req *entity.CreateBrandRequest) (*entity.CreateBrandResponse, error)
+	GetBrand(ctx context.Context, req *entity.GetBrandRequest) (*entity.BrandItem, error)
+	GetBrands(ctx context.Context, req *entity.BrandsRequest) (*entity.BrandsResponse, error)
+	UpdateBrand(ctx context.Context, req *entity.UpdateBrandRequest) (*entity.UpdateBrandResponse, error)
+	UpdateLogo(ctx context.Context, id, filename string) (oldFilename *string, err error)
+	DeleteBrand(ctx context.Context, req *entity.DeleteBrandRequest) error
+}
+
+type BrandService interface {
+	CreateBrand(ctx context.Context, req *entity.CreateBrandRequest) (*entity.CreateBrandResponse, error)
+	GetBrand(ctx context.Context, req *entity.GetBrandRequest) (*entity.BrandItem, error)
+	GetBrands(ctx context.Context, req *entity.BrandsRequest) (*entity.BrandsResponse, error)
+	UpdateBrand(ctx context.Context, req *entity.UpdateBrandRequest) (*entity.UpdateBrandResponse, error)
+	UploadLogo(ctx context.Context, req *entity.UploadLogoRequest) (*entity.BrandItem, error)
+	DeleteBrand(ctx context.Context, req *entity.DeleteBrandRequest) error
+}
+
+// BrandStorage stores brand logos, implemented by storage_manager.S3Storage.
+type BrandStorage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,77 @@
+package repository
+
+const (
+	queryInsertBrand = `
+		INSERT INTO brands (
+			name,
+			slug
+		) VALUES (?, ?) RETURNING id, slug
+	`
+
+	queryGetBrandById = `
+		SELECT
+			id,
+			name,
+			slug,
+			logo_filename
+		FROM brands
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	queryGetBrands = `
+		SELECT
+			COUNT(id) OVER() as total_data,
+			id,
+			name,
+			slug,
+			logo_filename
+		FROM brands
+		WHERE
+			deleted_at IS NULL
+			AND (? = '' OR name ILIKE ? || '%')
+		ORDER BY name, id
+		LIMIT ? OFFSET ?
+	`
+
+	queryUpdateBrand = `
+		UPDATE brands
+		SET
+			name = ?,
+			slug = ?,
+			updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+		RETURNING id, slug
+	`
+
+	// queryRenameProductsBrand keeps the brand name copied on products (used by search) in sync.
+	queryRenameProductsBrand = `
+		UPDATE products
+		SET
+			brand = ?
+		WHERE brand_id = ?
+	`
+
+	// queryUpdateBrandLogo returns the previous logo, the subquery reads the row before the update.
+	queryUpdateBrandLogo = `
+		UPDATE brands b
+		SET
+			logo_filename = ?,
+			updated_at = NOW()
+		FROM (SELECT id, logo_filename FROM brands WHERE id = ? FOR UPDATE) old
+		WHERE b.id = old.id AND b.deleted_at IS NULL
+		RETURNING old.logo_filename
+	`
+
+	queryCountBrandUsage = `
+		SELECT COUNT(id)
+		FROM products
+		WHERE brand_id = ? AND deleted_at IS NULL
+	`
+
+	querySoftDeleteBrand = `
+		UPDATE brands
+		SET
+			deleted_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+	`
+)
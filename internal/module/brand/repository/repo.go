@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/brand/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/brand/ports"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.BrandRepository = &brandRepository{}
+
+type brandRepository struct {
+	db *sqlx.DB
+}
+
+func NewBrandRepository(db *sqlx.DB) *brandRepository {
+	return &brandRepository{
+		db: db,
+	}
+}
+
+func (r *brandRepository) CreateBrand(ctx context.Context, req *entity.CreateBrandRequest) (*entity.CreateBrandResponse, error) {
+	var resp = new(entity.CreateBrandResponse)
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(queryInsertBrand), req.Name, req.Slug).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateBrand - Failed to create brand")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *brandRepository) GetBrand(ctx context.Context, req *entity.GetBrandRequest) (*entity.BrandItem, error) {
+	var resp = new(entity.BrandItem)
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(queryGetBrandById), req.Id).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetBrand - Failed to get brand")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *brandRepository) GetBrands(ctx context.Context, req *entity.BrandsRequest) (*entity.BrandsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.BrandItem
+	}
+
+	var (
+		resp = new(entity.BrandsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.BrandItem, 0, req.Paginate)
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(queryGetBrands),
+		req.Q,
+		req.Q,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetBrands - Failed to get brands")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.BrandItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *brandRepository) UpdateBrand(ctx context.Context, req *entity.UpdateBrandRequest) (*entity.UpdateBrandResponse, error) {
+	var resp = new(entity.UpdateBrandResponse)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::UpdateBrand - Failed to begin transaction")
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error().Err(errRollback).Msg("repository::UpdateBrand - Failed to rollback transaction")
+			}
+		}
+	}()
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(queryUpdateBrand), req.Name, req.Slug, req.Id).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateBrand - Failed to update brand")
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(queryRenameProductsBrand), req.Name, req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateBrand - Failed to rename products brand")
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("repository::UpdateBrand - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *brandRepository) UpdateLogo(ctx context.Context, id, filename string) (*string, error) {
+	var oldFilename *string
+
+	err := r.db.QueryRowContext(ctx, r.db.Rebind(queryUpdateBrandLogo), filename, id).Scan(&oldFilename)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Str("filename", filename).Msg("repository::UpdateLogo - Failed to update brand logo")
+		return nil, err
+	}
+
+	return oldFilename, nil
+}
+
+func (r *brandRepository) DeleteBrand(ctx context.Context, req *entity.DeleteBrandRequest) error {
+	var usage int
+
+	err := r.db.QueryRowContext(ctx, r.db.Rebind(queryCountBrandUsage), req.Id).Scan(&usage)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteBrand - Failed to count brand usage")
+		return err
+	}
+
+	if usage > 0 {
+		return entity.ErrBrandInUse
+	}
+
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(querySoftDeleteBrand), req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteBrand - Failed to delete brand")
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteBrand - Failed to get affected rows")
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/brand/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/brand/ports"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/slug"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/storage_manager"
+)
+
+const maxLogoSize = 2 << 20 // 2 MB
+
+// logoExtensions maps the sniffed content type of an allowed logo to its file extension.
+var logoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+var _ ports.BrandService = &brandService{}
+
+type brandService struct {
+	repo    ports.BrandRepository
+	storage ports.BrandStorage
+}
+
+func NewBrandService(repo ports.BrandRepository, storage ports.BrandStorage) *brandService {
+	return &brandService{
+		repo:    repo,
+		storage: storage,
+	}
+}
+
+func (s *brandService) CreateBrand(ctx context.Context, req *entity.CreateBrandRequest) (*entity.CreateBrandResponse, error) {
+	if req.Slug == "" {
+		req.Slug = slug.Make(req.Name)
+	}
+
+	return s.repo.CreateBrand(ctx, req)
+}
+
+func (s *brandService) GetBrand(ctx context.Context, req *entity.GetBrandRequest) (*entity.BrandItem, error) {
+	resp, err := s.repo.GetBrand(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Brand not found"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp.LogoUrl = logoURL(resp.LogoFilename)
+
+	return resp, nil
+}
+
+func (s *brandService) GetBrands(ctx context.Context, req *entity.BrandsRequest) (*entity.BrandsResponse, error) {
+	resp, err := s.repo.GetBrands(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range resp.Items {
+		resp.Items[i].LogoUrl = logoURL(resp.Items[i].LogoFilename)
+	}
+
+	return resp, nil
+}
+
+func (s *brandService) UpdateBrand(ctx context.Context, req *entity.UpdateBrandRequest) (*entity.UpdateBrandResponse, error) {
+	if req.Slug == "" {
+		req.Slug = slug.Make(req.Name)
+	}
+
+	resp, err := s.repo.UpdateBrand(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Brand not found"))
+	}
+
+	return resp, err
+}
+
+func (s *brandService) UploadLogo(ctx context.Context, req *entity.UploadLogoRequest) (*entity.BrandItem, error) {
+	if req.Logo.Size > maxLogoSize {
+		return nil, errmsg.NewCustomErrors(fiber.StatusBadRequest,
+			errmsg.WithMessage("Invalid logo"),
+			errmsg.WithErrors("logo", "logo must not be larger than 2 MB."),
+		)
+	}
+
+	contentType, err := storage_manager.DetectContentType(req.Logo)
+	if err != nil {
+		log.Error().Err(err).Str("filename", req.Logo.Filename).Msg("service::UploadLogo - Failed to read logo")
+		return nil, err
+	}
+
+	ext, ok := logoExtensions[contentType]
+	if !ok {
+		return nil, errmsg.NewCustomErrors(fiber.StatusBadRequest,
+			errmsg.WithMessage("Invalid logo"),
+			errmsg.WithErrors("logo", "logo must be a jpeg, png or webp file."),
+		)
+	}
+
+	var (
+		filename = fmt.Sprintf("brands/%s/%s%s", req.Id, uuid.NewString(), ext)
+		key      = storage_manager.ObjectKey(filename, false)
+	)
+
+	file, err := req.Logo.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if err := s.storage.Put(ctx, key, file, req.Logo.Size, contentType); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("service::UploadLogo - Failed to upload logo")
+		return nil, err
+	}
+
+	oldFilename, err := s.repo.UpdateLogo(ctx, req.Id, filename)
+	if err != nil {
+		s.deleteObject(ctx, key)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Brand not found"))
+		}
+		return nil, err
+	}
+
+	if oldFilename != nil {
+		s.deleteObject(ctx, storage_manager.ObjectKey(*oldFilename, false))
+	}
+
+	return s.GetBrand(ctx, &entity.GetBrandRequest{Id: req.Id})
+}
+
+func (s *brandService) DeleteBrand(ctx context.Context, req *entity.DeleteBrandRequest) error {
+	err := s.repo.DeleteBrand(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Brand not found"))
+	}
+
+	if errors.Is(err, entity.ErrBrandInUse) {
+		return errmsg.NewCustomErrors(fiber.StatusConflict, errmsg.WithMessage("Brand is still used by products"))
+	}
+
+	return err
+}
+
+// deleteObject removes a stored logo on a best effort basis, failures are only logged.
+func (s *brandService) deleteObject(ctx context.Context, key string) {
+	if err := s.storage.Delete(ctx, key); err != nil {
+		log.Error().Err(err).Str("key", key).Msg("service::deleteObject - Failed to delete object")
+	}
+}
+
+func logoURL(filename *string) string {
+	if filename == nil {
+		return ""
+	}
+
+	return storage_manager.GeneratePublicURL(*filename)
+}
@@ -0,0 +1,44 @@
+package entity
+
+import "errors"
+
+var ErrCategoryInUse = errors.New("category still has subcategories or products")
+
+type CreateCategoryRequest struct {
+	ParentId *string `json:"parent_id" validate:"omitempty,uuid" db:"parent_id"`
+	Name     string  `json:"name" validate:"required,max=100" db:"name"`
+	Slug     string  `json:"slug" validate:"omitempty,max=120,slug" db:"slug"` // made from the name when empty
+}
+
+type CreateCategoryResponse struct {
+	Id   string `json:"id" db:"id"`
+	Slug string `json:"slug" db:"slug"`
+}
+
+type CategoryItem struct {
+	Id       string  `json:"id" db:"id"`
+	ParentId *string `json:"parent_id" db:"parent_id"`
+	Name     string  `json:"name" db:"name"`
+	Slug     string  `json:"slug" db:"slug"`
+}
+
+type CategoryNode struct {
+	CategoryItem
+	Children []*CategoryNode `json:"children"`
+}
+
+type UpdateCategoryRequest struct {
+	Id       string  `params:"id" validate:"uuid" db:"id"`
+	ParentId *string `json:"parent_id" validate:"omitempty,uuid" db:"parent_id"`
+	Name     string  `json:"name" validate:"required,max=100" db:"name"`
+	Slug     string  `json:"slug" validate:"omitempty,max=120,slug" db:"slug"`
+}
+
+type UpdateCategoryResponse struct {
+	Id   string `json:"id" db:"id"`
+	Slug string `json:"slug" db:"slug"`
+}
+
+type DeleteCategoryRequest struct {
+	Id string `params:"id" validate:"uuid" db:"id"`
+}
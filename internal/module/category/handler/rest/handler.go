@@ -0,0 +1,126 @@
+package rest
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/adapter"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/middleware"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/category/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/category/ports"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/category/repository"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/category/service"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/response"
+	"github.com/rs/zerolog/log"
+)
+
+type categoryHandler struct {
+	service ports.CategoryService
+}
+
+func NewCategoryHandler() *categoryHandler {
+	var (
+		handler = new(categoryHandler)
+		repo    = repository.NewCategoryRepository(adapter.Adapters.ShopeefunPostgres)
+		service = service.NewCategoryService(repo)
+	)
+	handler.service = service
+
+	return handler
+}
+
+func (h *categoryHandler) Register(router fiber.Router) {
+	router.Get("/categories", h.GetCategoryTree)
+	router.Post("/categories", middleware.UserIdHeader, middleware.AdminOnly, h.CreateCategory)
+	router.Patch("/categories/:id", middleware.UserIdHeader, middleware.AdminOnly, h.UpdateCategory)
+	router.Delete("/categories/:id", middleware.UserIdHeader, middleware.AdminOnly, h.DeleteCategory)
+}
+
+func (h *categoryHandler) CreateCategory(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.CreateCategoryRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::CreateCategory - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::CreateCategory - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.CreateCategory(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, ""))
+}
+
+func (h *categoryHandler) GetCategoryTree(c *fiber.Ctx) error {
+	resp, err := h.service.GetCategoryTree(c.Context())
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *categoryHandler) UpdateCategory(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.UpdateCategoryRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateCategory - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.Id = c.Params("id")
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::UpdateCategory - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.UpdateCategory(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *categoryHandler) DeleteCategory(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.DeleteCategoryRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	req.Id = c.Params("id")
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteCategory - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.DeleteCategory(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
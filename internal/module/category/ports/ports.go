@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/category/entity"
+)
+
+type CategoryRepository interface {
+	CreateCategory(ctx context.Context, req *entity.CreateCategoryRequest) (*entity.CreateCategoryResponse, error)
+	GetCategories(ctx context.Context) ([]entity.CategoryItem, error)
+	IsDescendant(ctx context.Context, id, descendantId string) (bool, error)
+	UpdateCategory(ctx context.Context, req *entity.UpdateCategoryRequest) (*entity.UpdateCategoryResponse, error)
+	DeleteCategory(ctx context.Context, req *entity.DeleteCategoryRequest) error
+}
+
+type CategoryService interface {
+	CreateCategory(ctx context.Context, req *entity.CreateCategoryRequest) (*entity.CreateCategoryResponse, error)
+	GetCategoryTree(ctx context.Context) ([]*entity.CategoryNode, error)
+	UpdateCategory(ctx context.Context, req *entity.UpdateCategoryRequest) (*entity.UpdateCategoryResponse, error)
+	DeleteCategory(ctx context.Context, req *entity.DeleteCategoryRequest) error
+}
@@ -0,0 +1,72 @@
+package repository
+
+const (
+	queryInsertCategory = `
+		INSERT INTO categories (
+			parent_id,
+			name,
+			slug
+		)
+		SELECT ?, ?, ?
+		WHERE CAST(? AS UUID) IS NULL OR EXISTS (
+			SELECT 1 FROM categories WHERE id = ? AND deleted_at IS NULL
+		)
+		RETURNING id, slug
+	`
+
+	queryGetCategories = `
+		SELECT
+			id,
+			parent_id,
+			name,
+			slug
+		FROM categories
+		WHERE deleted_at IS NULL
+		ORDER BY name, id
+	`
+
+	// queryIsDescendant checks whether the second id is the first one or below it.
+	queryIsDescendant = `
+		WITH RECURSIVE tree AS (
+			SELECT id FROM categories WHERE id = ?
+			UNION ALL
+			SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+		)
+		SELECT EXISTS (SELECT 1 FROM tree WHERE id = ?)
+	`
+
+	queryUpdateCategory = `
+		UPDATE categories
+		SET
+			parent_id = ?,
+			name = ?,
+			slug = ?,
+			updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+			AND (CAST(? AS UUID) IS NULL OR EXISTS (
+				SELECT 1 FROM categories WHERE id = ? AND deleted_at IS NULL
+			))
+		RETURNING id, slug
+	`
+
+	// queryRenameProductsCategory keeps the category name copied on products (used by search) in sync.
+	queryRenameProductsCategory = `
+		UPDATE products
+		SET
+			category = ?
+		WHERE category_id = ?
+	`
+
+	queryCountCategoryUsage = `
+		SELECT
+			(SELECT COUNT(id) FROM categories WHERE parent_id = ? AND deleted_at IS NULL) +
+			(SELECT COUNT(id) FROM products WHERE category_id = ? AND deleted_at IS NULL)
+	`
+
+	querySoftDeleteCategory = `
+		UPDATE categories
+		SET
+			deleted_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+	`
+)
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/category/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/category/ports"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.CategoryRepository = &categoryRepository{}
+
+type categoryRepository struct {
+	db *sqlx.DB
+}
+
+func NewCategoryRepository(db *sqlx.DB) *categoryRepository {
+	return &categoryRepository{
+		db: db,
+	}
+}
+
+func (r *categoryRepository) CreateCategory(ctx context.Context, req *entity.CreateCategoryRequest) (*entity.CreateCategoryResponse, error) {
+	var resp = new(entity.CreateCategoryResponse)
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(queryInsertCategory),
+		req.ParentId,
+		req.Name,
+		req.Slug,
+		req.ParentId,
+		req.ParentId,
+	).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateCategory - Failed to create category")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *categoryRepository) GetCategories(ctx context.Context) ([]entity.CategoryItem, error) {
+	var resp = make([]entity.CategoryItem, 0)
+
+	err := r.db.SelectContext(ctx, &resp, queryGetCategories)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::GetCategories - Failed to get categories")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *categoryRepository) IsDescendant(ctx context.Context, id, descendantId string) (bool, error) {
+	var isDescendant bool
+
+	err := r.db.QueryRowContext(ctx, r.db.Rebind(queryIsDescendant), id, descendantId).Scan(&isDescendant)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Str("descendant_id", descendantId).Msg("repository::IsDescendant - Failed to check descendant")
+		return false, err
+	}
+
+	return isDescendant, nil
+}
+
+func (r *categoryRepository) UpdateCategory(ctx context.Context, req *entity.UpdateCategoryRequest) (*entity.UpdateCategoryResponse, error) {
+	var resp = new(entity.UpdateCategoryResponse)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::UpdateCategory - Failed to begin transaction")
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error().Err(errRollback).Msg("repository::UpdateCategory - Failed to rollback transaction")
+			}
+		}
+	}()
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(queryUpdateCategory),
+		req.ParentId,
+		req.Name,
+		req.Slug,
+		req.Id,
+		req.ParentId,
+		req.ParentId,
+	).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateCategory - Failed to update category")
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(queryRenameProductsCategory), req.Name, req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateCategory - Failed to rename products category")
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("repository::UpdateCategory - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *categoryRepository) DeleteCategory(ctx context.Context, req *entity.DeleteCategoryRequest) error {
+	var usage int
+
+	err := r.db.QueryRowContext(ctx, r.db.Rebind(queryCountCategoryUsage), req.Id, req.Id).Scan(&usage)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteCategory - Failed to count category usage")
+		return err
+	}
+
+	if usage > 0 {
+		return entity.ErrCategoryInUse
+	}
+
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(querySoftDeleteCategory), req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteCategory - Failed to delete category")
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteCategory - Failed to get affected rows")
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
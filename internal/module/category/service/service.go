@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/category/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/category/ports"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/slug"
+)
+
+var _ ports.CategoryService = &categoryService{}
+
+type categoryService struct {
+	repo ports.CategoryRepository
+}
+
+func NewCategoryService(repo ports.CategoryRepository) *categoryService {
+	return &categoryService{
+		repo: repo,
+	}
+}
+
+func (s *categoryService) CreateCategory(ctx context.Context, req *entity.CreateCategoryRequest) (*entity.CreateCategoryResponse, error) {
+	if req.Slug == "" {
+		req.Slug = slug.Make(req.Name)
+	}
+
+	resp, err := s.repo.CreateCategory(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusBadRequest,
+			errmsg.WithMessage("Parent category not found"),
+			errmsg.WithErrors("parent_id", "parent category not found."),
+		)
+	}
+
+	return resp, err
+}
+
+func (s *categoryService) GetCategoryTree(ctx context.Context) ([]*entity.CategoryNode, error) {
+	categories, err := s.repo.GetCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		roots = make([]*entity.CategoryNode, 0)
+		nodes = make(map[string]*entity.CategoryNode, len(categories))
+	)
+
+	for _, category := range categories {
+		nodes[category.Id] = &entity.CategoryNode{
+			CategoryItem: category,
+			Children:     make([]*entity.CategoryNode, 0),
+		}
+	}
+
+	// categories are ordered by name, so children keep that order too
+	for _, category := range categories {
+		node := nodes[category.Id]
+
+		if category.ParentId == nil {
+			roots = append(roots, node)
+			continue
+		}
+
+		parent, ok := nodes[*category.ParentId]
+		if !ok {
+			log.Warn().Any("category", category).Msg("service::GetCategoryTree - Parent category is deleted")
+			continue
+		}
+
+		parent.Children = append(parent.Children, node)
+	}
+
+	return roots, nil
+}
+
+func (s *categoryService) UpdateCategory(ctx context.Context, req *entity.UpdateCategoryRequest) (*entity.UpdateCategoryResponse, error) {
+	if req.Slug == "" {
+		req.Slug = slug.Make(req.Name)
+	}
+
+	if req.ParentId != nil {
+		isDescendant, err := s.repo.IsDescendant(ctx, req.Id, *req.ParentId)
+		if err != nil {
+			return nil, err
+		}
+
+		if isDescendant {
+			return nil, errmsg.NewCustomErrors(fiber.StatusBadRequest,
+				errmsg.WithMessage("Invalid parent category"),
+				errmsg.WithErrors("parent_id", "a category can not be moved below itself or its subcategories."),
+			)
+		}
+	}
+
+	resp, err := s.repo.UpdateCategory(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Category or parent category not found"))
+	}
+
+	return resp, err
+}
+
+func (s *categoryService) DeleteCategory(ctx context.Context, req *entity.DeleteCategoryRequest) error {
+	err := s.repo.DeleteCategory(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Category not found"))
+	}
+
+	if errors.Is(err, entity.ErrCategoryInUse) {
+		return errmsg.NewCustomErrors(fiber.StatusConflict, errmsg.WithMessage("Category still has subcategories or products"))
+	}
+
+	return err
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrRelayStopped stops a relay batch, the events left are published by the next run.
+var ErrRelayStopped = errors.New("relay stopped")
+
+// Aggregates, the events of an aggregate are published in the order they were written.
+const (
+	AggregateProduct = "product"
+	AggregateShop    = "shop"
+)
+
+// Event types. The payload of a product or shop event is its row as it is after the write,
+// the payload of stock.changed is the stock movement with the shop_id of its product.
+const (
+	ProductCreated = "product.created"
+	ProductUpdated = "product.updated"
+	ProductDeleted = "product.deleted"
+	StockChanged   = "stock.changed"
+	ShopCreated    = "shop.created"
+	ShopUpdated    = "shop.updated"
+	ShopDeleted    = "shop.deleted"
+)
+
+// Event is an event of the outbox waiting to be published.
+type Event struct {
+	Id            int64     `db:"id"` // publication order
+	EventId       string    `db:"event_id"`
+	AggregateType string    `db:"aggregate_type"`
+	AggregateId   string    `db:"aggregate_id"`
+	EventType     string    `db:"event_type"`
+	Payload       []byte    `db:"payload"`
+	Attempts      int       `db:"attempts"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+// Envelope is the message published for an event, consumers drop the events they already handled by id.
+type Envelope struct {
+	Id            string          `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateId   string          `json:"aggregate_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Data          json.RawMessage `json:"data"`
+}
@@ -0,0 +1,55 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/adapter"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/infrastructure/config"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/outbox/ports"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/outbox/repository"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/outbox/service"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/scheduler"
+	"github.com/rs/zerolog/log"
+)
+
+type outboxJob struct {
+	service ports.OutboxService
+}
+
+func NewOutboxJob() *outboxJob {
+	var (
+		job     = new(outboxJob)
+		repo    = repository.NewOutboxRepository(adapter.Adapters.ShopeefunPostgres)
+		service = service.NewOutboxService(repo, adapter.Adapters.Publisher, config.Envs.Outbox.SubjectPrefix, config.Envs.Outbox.MaxAttempts)
+	)
+	job.service = service
+
+	return job
+}
+
+func (j *outboxJob) Register(s *scheduler.Scheduler) {
+	s.Every("relay_outbox_events", time.Second, j.Relay)
+	s.Every("purge_published_events", time.Hour, j.PurgePublished)
+}
+
+func (j *outboxJob) Relay(ctx context.Context) error {
+	published, err := j.service.Relay(ctx)
+	if published > 0 {
+		log.Info().Int("published", published).Msg("job::Relay - Published outbox events")
+	}
+
+	return err
+}
+
+// PurgePublished deletes the events published longer than the retention period ago.
+func (j *outboxJob) PurgePublished(ctx context.Context) error {
+	before := time.Now().AddDate(0, 0, -config.Envs.Outbox.RetentionDays)
+
+	purged, err := j.service.PurgePublished(ctx, before)
+	if purged > 0 {
+		log.Info().Int("purged", purged).Msg("job::PurgePublished - Purged published outbox events")
+	}
+
+	return err
+}
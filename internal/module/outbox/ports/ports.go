@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/outbox/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/publisher"
+)
+
+type OutboxRepository interface {
+	RelayEvents(ctx context.Context, limit, maxAttempts int, publish func(event *entity.Event) error) (read int, published int, err error)
+	DeletePublishedEvents(ctx context.Context, before time.Time) (int, error)
+}
+
+type OutboxService interface {
+	Relay(ctx context.Context) (int, error)
+	PurgePublished(ctx context.Context, before time.Time) (int, error)
+}
+
+// OutboxPublisher publishes the events to the broker, implemented by the publisher package.
+type OutboxPublisher interface {
+	Publish(ctx context.Context, msg *publisher.Message) error
+}
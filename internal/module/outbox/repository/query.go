@@ -0,0 +1,47 @@
+package repository
+
+const (
+	// queryLockRelay lets a single relay publish at a time, concurrent relays would break the order of the events.
+	queryLockRelay = `
+		SELECT pg_try_advisory_xact_lock(hashtext('outbox_relay'))
+	`
+
+	queryGetPendingEvents = `
+		SELECT
+			id,
+			event_id,
+			aggregate_type,
+			aggregate_id,
+			event_type,
+			payload,
+			attempts,
+			created_at
+		FROM outbox_events
+		WHERE published_at IS NULL AND dead_at IS NULL
+		ORDER BY id
+		LIMIT ?
+	`
+
+	queryMarkEventsPublished = `
+		UPDATE outbox_events
+		SET
+			published_at = NOW()
+		WHERE id = ANY(CAST(? AS BIGINT[]))
+	`
+
+	// queryFailEvent moves the event to the dead letters when its first param is true.
+	queryFailEvent = `
+		UPDATE outbox_events
+		SET
+			attempts = attempts + 1,
+			last_error = ?,
+			dead_at = CASE WHEN ? THEN NOW() END
+		WHERE id = ?
+	`
+
+	// queryDeletePublishedEvents keeps the events the webhooks dispatcher has not read yet.
+	queryDeletePublishedEvents = `
+		DELETE FROM outbox_events
+		WHERE published_at < ? AND dispatched_at IS NOT NULL
+	`
+)
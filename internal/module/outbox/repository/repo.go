@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/outbox/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/outbox/ports"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.OutboxRepository = &outboxRepository{}
+
+type outboxRepository struct {
+	db *sqlx.DB
+}
+
+func NewOutboxRepository(db *sqlx.DB) *outboxRepository {
+	return &outboxRepository{
+		db: db,
+	}
+}
+
+// withTx runs fn inside a transaction, rolling it back when fn returns an error.
+func (r *outboxRepository) withTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::withTx - Failed to begin transaction")
+		return err
+	}
+
+	if err = fn(tx); err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			log.Error().Err(errRollback).Msg("repository::withTx - Failed to rollback transaction")
+		}
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("repository::withTx - Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// RelayEvents calls publish with at most limit pending events in id order and marks the published ones.
+// A failed event holds back the next events of its aggregate until it is published, ErrRelayStopped also
+// holds back the rest of the batch. An event failing maxAttempts times is moved to the dead letters and
+// releases its aggregate, the failures of ErrRelayStopped do not count as they are not the event's.
+// An event is marked after it is published, so it is published again when the transaction does not commit.
+// Nothing is read while another relay holds the lock.
+func (r *outboxRepository) RelayEvents(ctx context.Context, limit, maxAttempts int, publish func(event *entity.Event) error) (int, int, error) {
+	var read, published int
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var locked bool
+		if err := tx.QueryRowContext(ctx, queryLockRelay).Scan(&locked); err != nil {
+			return err
+		}
+
+		if !locked {
+			return nil
+		}
+
+		var events []entity.Event
+		if err := tx.SelectContext(ctx, &events, tx.Rebind(queryGetPendingEvents), limit); err != nil {
+			return err
+		}
+		read = len(events)
+
+		var (
+			ids  = make([]int64, 0, len(events))
+			held = make(map[string]bool) // aggregates with a failed event
+		)
+
+		for i := range events {
+			event := &events[i]
+
+			key := event.AggregateType + ":" + event.AggregateId
+			if held[key] {
+				continue
+			}
+
+			errPublish := publish(event)
+			if errPublish == nil {
+				ids = append(ids, event.Id)
+				continue
+			}
+
+			held[key] = true
+
+			stopped := errors.Is(errPublish, entity.ErrRelayStopped)
+			dead := !stopped && event.Attempts+1 >= maxAttempts
+			if dead {
+				log.Error().Err(errPublish).Int64("id", event.Id).Str("event_id", event.EventId).
+					Msg("repository::RelayEvents - Event moved to the dead letters")
+			}
+
+			_, err := tx.ExecContext(ctx, tx.Rebind(queryFailEvent), errPublish.Error(), dead, event.Id)
+			if err != nil {
+				return err
+			}
+
+			if stopped {
+				break
+			}
+		}
+
+		published = len(ids)
+		if published == 0 {
+			return nil
+		}
+
+		_, err := tx.ExecContext(ctx, tx.Rebind(queryMarkEventsPublished), pq.Array(ids))
+		return err
+	})
+	if err != nil {
+		log.Error().Err(err).Int("limit", limit).Msg("repository::RelayEvents - Failed to relay events")
+		return read, 0, err
+	}
+
+	return read, published, nil
+}
+
+// DeletePublishedEvents deletes the events published before the given time and returns how many were deleted.
+func (r *outboxRepository) DeletePublishedEvents(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(queryDeletePublishedEvents), before)
+	if err != nil {
+		log.Error().Err(err).Time("before", before).Msg("repository::DeletePublishedEvents - Failed to delete published events")
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
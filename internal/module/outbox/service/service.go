@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/outbox/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/outbox/ports"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/publisher"
+)
+
+// relayBatchSize is how many events are published per transaction.
+const relayBatchSize = 100
+
+var _ ports.OutboxService = &outboxService{}
+
+type outboxService struct {
+	repo          ports.OutboxRepository
+	publisher     ports.OutboxPublisher
+	subjectPrefix string
+	maxAttempts   int
+}
+
+func NewOutboxService(repo ports.OutboxRepository, publisher ports.OutboxPublisher, subjectPrefix string, maxAttempts int) *outboxService {
+	return &outboxService{
+		repo:          repo,
+		publisher:     publisher,
+		subjectPrefix: subjectPrefix,
+		maxAttempts:   maxAttempts,
+	}
+}
+
+// Relay publishes the pending events batch by batch and returns how many were published. It stops after
+// a batch with a failed event, the held back events are retried by the next run.
+func (s *outboxService) Relay(ctx context.Context) (int, error) {
+	var total int
+
+	for {
+		read, published, err := s.repo.RelayEvents(ctx, relayBatchSize, s.maxAttempts, func(event *entity.Event) error {
+			return s.publish(ctx, event)
+		})
+		total += published
+		if err != nil {
+			return total, err
+		}
+
+		if read < relayBatchSize || published < read {
+			return total, nil
+		}
+	}
+}
+
+func (s *outboxService) publish(ctx context.Context, event *entity.Event) error {
+	payload, err := json.Marshal(entity.Envelope{
+		Id:            event.EventId,
+		Type:          event.EventType,
+		AggregateType: event.AggregateType,
+		AggregateId:   event.AggregateId,
+		OccurredAt:    event.CreatedAt,
+		Data:          event.Payload,
+	})
+	if err != nil {
+		log.Error().Err(err).Int64("id", event.Id).Msg("service::publish - Failed to encode event")
+		return err
+	}
+
+	err = s.publisher.Publish(ctx, &publisher.Message{
+		Id:      event.EventId,
+		Subject: s.subjectPrefix + "." + event.EventType,
+		Key:     event.AggregateId,
+		Payload: payload,
+	})
+	if errors.Is(err, publisher.ErrUnavailable) {
+		// the next events would wait for the same broker
+		return fmt.Errorf("%w: %w", entity.ErrRelayStopped, err)
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("id", event.Id).Int("attempts", event.Attempts+1).Msg("service::publish - Failed to publish event")
+		return err
+	}
+
+	return nil
+}
+
+// PurgePublished deletes the events published before the given time.
+func (s *outboxService) PurgePublished(ctx context.Context, before time.Time) (int, error) {
+	return s.repo.DeletePublishedEvents(ctx, before)
+}
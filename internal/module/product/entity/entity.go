@@ -1,63 +1,207 @@
 package entity
 
 import (
+	"errors"
+	"strings"
+	"time"
+
 	shop "github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/patch"
 	"github.com/hilmiikhsan/shopeefun-product-service/pkg/types"
 )
 
+var (
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrBrandNotFound    = errors.New("brand not found")
+	ErrStaleVersion     = errors.New("product was modified since the given version")
+)
+
 type CreateProductRequest struct {
 	ShopId string `json:"shop_id" validate:"uuid" db:"shop_id"`
+	UserId string `prop:"user_id" validate:"uuid" db:"user_id"`
 
 	Name        string  `json:"name" validate:"required" db:"name"`
 	Description string  `json:"description" validate:"required,max=255" db:"description"`
-	Category    string  `json:"category" validate:"required" db:"category"`
+	CategoryId  string  `json:"category_id" validate:"required,uuid" db:"category_id"`
+	BrandId     *string `json:"brand_id" validate:"omitempty,uuid" db:"brand_id"`
 	Price       float64 `json:"price" validate:"required" db:"price"`
-	Stock       int     `json:"stock" validate:"required" db:"stock"`
+	Stock       int     `json:"stock" validate:"min=0" db:"stock"`                          // recorded as the first restock
+	Status      string  `json:"status" validate:"omitempty,oneof=draft active" db:"status"` // draft when empty
 }
 
 type CreateProductResponse struct {
-	Id   string `json:"id" db:"id"`
-	Name string `json:"name" db:"name"`
+	Id     string `json:"id" db:"id"`
+	Name   string `json:"name" db:"name"`
+	Status string `json:"status" db:"status"`
 }
 
 type GetProductRequest struct {
+	UserId string `prop:"user_id" validate:"omitempty,uuid"` // the shop owner also sees the products that are not active
+
 	Id string `validate:"uuid" db:"id"`
 }
 
 type GetProductResponse struct {
-	Id          string        `json:"id" db:"product_id"`
-	Name        string        `json:"name" db:"product_name"`
-	Description string        `json:"description" db:"description"`
-	Category    string        `json:"category" db:"category"`
-	Price       float64       `json:"price" db:"price"`
-	Stock       int           `json:"stock" db:"stock"`
-	ShopDetail  shop.ShopItem `json:"shop_detail"`
+	Id             string        `json:"id" db:"product_id"`
+	Name           string        `json:"name" db:"product_name"`
+	Description    string        `json:"description" db:"description"`
+	CategoryId     *string       `json:"category_id" db:"category_id"`
+	Category       string        `json:"category" db:"category"`
+	BrandId        *string       `json:"brand_id" db:"brand_id"`
+	Brand          *string       `json:"brand" db:"brand"`
+	Status         string        `json:"status" db:"status"`
+	Price          float64       `json:"price" db:"price"`
+	OriginalPrice  float64       `json:"original_price" db:"-"`      // the price without the sale
+	EffectivePrice float64       `json:"effective_price" db:"-"`     // the sale price while a price schedule is active
+	Discount       float64       `json:"discount_percentage" db:"-"` // ex: 25 when 100 is on sale for 75
+	Stock          int           `json:"stock" db:"stock"`
+	MinPrice       float64       `json:"min_price" db:"min_price"`
+	MaxPrice       float64       `json:"max_price" db:"max_price"`
+	TotalStock     int           `json:"total_stock" db:"total_stock"`
+	AvailableStock int           `json:"available_stock" db:"available_stock"`
+	Rating         float64       `json:"rating" db:"rating"`
+	ReviewCount    int           `json:"review_count" db:"review_count"`
+	QuestionCount  int           `json:"question_count" db:"question_count"`
+	AnswerRate     float64       `json:"answer_rate" db:"-"` // percentage of the questions answered
+	FavoriteCount  int           `json:"favorite_count" db:"favorite_count"`
+	IsFavorited    bool          `json:"is_favorited" db:"is_favorited"` // whether the product is in the wishlist of the caller
+	Version        int           `json:"version" db:"version"`           // returned as the ETag header
+	Variants       []VariantItem `json:"variants"`
+	Images         []ImageItem   `json:"images"`
+	ShopDetail     shop.ShopItem `json:"shop_detail"`
 }
 
 type ProductItem struct {
-	Id          string  `json:"id" db:"id"`
-	Name        string  `json:"name" db:"name"`
-	Description string  `json:"description" db:"description"`
-	Category    string  `json:"category" db:"category"`
-	Price       float64 `json:"price" db:"price"`
-	Stock       int     `json:"stock" db:"stock"`
-	Rating      int     `json:"rating" db:"rating"`
+	Id             string    `json:"id" db:"id"`
+	Name           string    `json:"name" db:"name"`
+	Description    string    `json:"description" db:"description"`
+	CategoryId     *string   `json:"category_id" db:"category_id"`
+	Category       string    `json:"category" db:"category"`
+	BrandId        *string   `json:"brand_id" db:"brand_id"`
+	Brand          *string   `json:"brand" db:"brand"`
+	Status         string    `json:"status" db:"status"`
+	Price          float64   `json:"price" db:"price"`
+	Stock          int       `json:"stock" db:"stock"`
+	MinPrice       float64   `json:"min_price" db:"min_price"`
+	MaxPrice       float64   `json:"max_price" db:"max_price"`
+	TotalStock     int       `json:"total_stock" db:"total_stock"`
+	AvailableStock int       `json:"available_stock" db:"available_stock"` // total stock minus the reserved stock
+	Rating         float64   `json:"rating" db:"rating"`                   // average of the reviews, 0 when there are none
+	ReviewCount    int       `json:"review_count" db:"review_count"`
+	FavoriteCount  int       `json:"favorite_count" db:"favorite_count"`
+	IsFavorited    bool      `json:"is_favorited" db:"is_favorited"` // whether the product is in the wishlist of the viewer
+	ImageUrl       string    `json:"image_url" db:"-"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+
+	// filled only when searching with "q"
+	Rank                 float64 `json:"rank,omitempty" db:"rank"`
+	NameHighlight        string  `json:"name_highlight,omitempty" db:"name_highlight"`
+	DescriptionHighlight string  `json:"description_highlight,omitempty" db:"description_highlight"`
+
+	ImageFilename  string `json:"-" db:"image_filename"`
+	ImageIsPrivate bool   `json:"-" db:"image_is_private"`
+
+	// listed only to the owner in the shop listing
+	*ProductManagement
+}
+
+// ProductManagement are the fields a seller needs to manage a listed product.
+type ProductManagement struct {
+	SalePrice     *float64  `json:"sale_price" db:"sale_price"`
+	ReservedStock int       `json:"reserved_stock" db:"reserved_stock"`
+	Version       int       `json:"version" db:"version"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
 }
 
 type ProductRequest struct {
-	Page     int    `query:"page" validate:"required,min=1"`
-	Paginate int    `query:"paginate" validate:"required,min=1,max=100"`
-	Category string `query:"category" validate:"omitempty,alpha"`
-	MinPrice string `query:"min_price" validate:"omitempty,numeric"`
-	MaxPrice string `query:"max_price" validate:"omitempty,numeric"`
-	Brand    string `query:"brand" validate:"omitempty,alpha"`
-	Rating   string `query:"rating" validate:"omitempty,numeric"`
-	Name     string `query:"name" validate:"omitempty"`
+	Page       int    `query:"page" validate:"required,min=1"`
+	Paginate   int    `query:"paginate" validate:"required,min=1,max=100"`
+	Category   string `query:"category" validate:"omitempty,max=120"` // slug, also matches subcategories
+	CategoryId string `query:"category_id" validate:"omitempty,uuid"` // also matches subcategories
+	MinPrice   string `query:"min_price" validate:"omitempty,numeric"`
+	MaxPrice   string `query:"max_price" validate:"omitempty,numeric"`
+	Brand      string `query:"brand" validate:"omitempty,max=500"`  // comma separated brand slugs, ex: "h-m,3m"
+	Rating     string `query:"rating" validate:"omitempty,numeric"` // minimum average rating, ex: 4.5
+	Name       string `query:"name" validate:"omitempty"`
+	Q          string `query:"q" validate:"omitempty,max=200"`
+	Facets     string `query:"facets" validate:"omitempty,oneof_csv=category brand price rating"`
+	InStock    bool   `query:"in_stock"`
+	Cursor     string `query:"cursor" validate:"omitempty,base64rawurl"`
+	Status     string `query:"status" validate:"omitempty,oneof=draft active inactive archived"`
+
+	// Sort is one of the Sort* keys, see SortKey for the default order.
+	Sort string `query:"sort" validate:"omitempty,oneof=price_asc price_desc newest rating name"`
+
+	// decoded Cursor, set by the service
+	After *types.Cursor `query:"-" validate:"-"`
+
+	// the user listing the products, set by the handler. Buyers only see the active products,
+	// the products of the viewer's own shops are listed in every status.
+	ViewerId string `query:"-" validate:"omitempty,uuid"`
+
+	// the shop of the shop listing, set by the handler
+	ShopId string `query:"-" validate:"omitempty,uuid"`
+
+	// lists the management fields, set by the service when the viewer owns the listed shop
+	Manage bool `query:"-" validate:"-"`
+
+	// lists only the products of the viewer's own shops, set by the service
+	Mine bool `query:"-" validate:"-"`
+}
+
+// Sort orders of the product listing, every order ends with the product id as tiebreaker.
+const (
+	SortPriceAsc  = "price_asc"  // lowest variant price, cheapest first
+	SortPriceDesc = "price_desc" // lowest variant price, most expensive first
+	SortNewest    = "newest"     // created_at, latest first
+	SortRating    = "rating"     // average review rating, highest first
+	SortName      = "name"       // name, A to Z
+	SortRelevance = "relevance"  // search rank, only when searching with "q"
+)
+
+// SortKey returns the order of the listing. When no sort is given,
+// search results are ordered by relevance and every other listing by newest.
+func (r *ProductRequest) SortKey() string {
+	if r.Sort != "" {
+		return r.Sort
+	}
+
+	if r.Q != "" {
+		return SortRelevance
+	}
+
+	return SortNewest
+}
+
+// SortKind returns the type of the sort column of an order, the values of its cursors are parsed as it.
+func SortKind(sort string) types.CursorKind {
+	switch sort {
+	case SortNewest:
+		return types.CursorTime
+	case SortName:
+		return types.CursorText
+	default:
+		return types.CursorNumber
+	}
+}
+
+// BrandList returns the requested brand slugs, ex: "h-m, 3m" => [h-m 3m].
+func (r *ProductRequest) BrandList() []string {
+	brands := make([]string, 0)
+
+	for _, brand := range strings.Split(r.Brand, ",") {
+		if brand = strings.TrimSpace(brand); brand != "" {
+			brands = append(brands, brand)
+		}
+	}
+
+	return brands
 }
 
 type ProductsResponse struct {
-	Items []ProductItem `json:"items"`
-	Meta  types.Meta    `json:"meta"`
+	Items  []ProductItem  `json:"items"`
+	Meta   types.Meta     `json:"meta"`
+	Facets *ProductFacets `json:"facets,omitempty"`
 }
 
 func (r *ProductRequest) SetDefault() {
@@ -71,23 +215,35 @@ func (r *ProductRequest) SetDefault() {
 }
 
 type UpdateProductRequest struct {
-	ShopId string `prop:"shop_id" validate:"uuid" db:"shop_id"`
-
-	Id          string  `params:"id" validate:"uuid" db:"id"`
-	Name        string  `json:"name" validate:"required" db:"name"`
-	Description string  `json:"description" validate:"required" db:"description"`
-	Category    string  `json:"category" validate:"required" db:"category"`
-	Price       float64 `json:"price" validate:"required" db:"price"`
-	Stock       int     `json:"stock" validate:"required" db:"stock"`
+	ShopId string `json:"-" validate:"-" db:"shop_id"` // the shop of the product, set by the service once the user is found to own it
+	UserId string `prop:"user_id" validate:"uuid" db:"user_id"`
+
+	// A JSON merge patch, only the fields given in the body are validated and updated.
+	// brand_id can be set to null to remove the brand, the other fields can not be null.
+	Id          string   `params:"id" validate:"uuid" db:"id"`
+	Name        *string  `json:"name" validate:"omitempty,min=1" db:"name"`
+	Description *string  `json:"description" validate:"omitempty,max=255" db:"description"`
+	CategoryId  *string  `json:"category_id" validate:"omitempty,uuid" db:"category_id"`
+	BrandId     *string  `json:"brand_id" validate:"omitempty,uuid" db:"brand_id"`
+	Price       *float64 `json:"price" validate:"omitempty,gt=0" db:"price"`
+	Stock       *int     `json:"stock" validate:"omitempty,min=0" db:"stock"` // the difference is recorded as an adjustment
+
+	Fields  patch.Fields `json:"-" validate:"-"` // the members of the body, set by the handler
+	IfMatch string       `json:"-" validate:"-"` // the If-Match header, the update is refused when the product changed since
 }
 
+// UpdateProductFields are the members of an UpdateProductRequest patch.
+var UpdateProductFields = []string{"name", "description", "category_id", "brand_id", "price", "stock"}
+
 type UpdateProductResponse struct {
-	Id   string `json:"id" db:"id"`
-	Name string `json:"name" db:"name"`
+	Id      string `json:"id" db:"id"`
+	Name    string `json:"name" db:"name"`
+	Version int    `json:"version" db:"version"`
 }
 
 type DeleteProductRequest struct {
-	ShopId string `prop:"shop_id" validate:"uuid" db:"shop_id"`
+	ShopId string `json:"-" validate:"-" db:"shop_id"` // the shop of the product, set by the service once the user is found to own it
+	UserId string `prop:"user_id" validate:"uuid" db:"user_id"`
 
 	Id string `params:"id" validate:"uuid" db:"id"`
 }
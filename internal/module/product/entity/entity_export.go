@@ -0,0 +1,48 @@
+package entity
+
+import "strings"
+
+// Formats of a catalog export.
+const (
+	ExportCSV    = "csv"
+	ExportNDJSON = "ndjson"
+	ExportXLSX   = "xlsx"
+)
+
+// ExportProductsRequest exports every product of a shop matching the filters of the product listing,
+// in the order of its Sort. The paging, cursor and facets of the listing are ignored.
+type ExportProductsRequest struct {
+	ShopId string `params:"id" validate:"uuid"`
+	UserId string `prop:"user_id" validate:"uuid"`
+	Format string `query:"format" validate:"required,oneof=csv ndjson xlsx"`
+
+	ProductRequest
+}
+
+// Detach returns a copy of the request owning its strings. The strings parsed by fiber point into the
+// buffers of the fasthttp request, which must not be read by an export streaming after its handler returned.
+func (r *ExportProductsRequest) Detach() *ExportProductsRequest {
+	d := *r
+	for _, s := range []*string{
+		&d.ShopId, &d.UserId, &d.Format, &d.Category, &d.CategoryId, &d.MinPrice, &d.MaxPrice, &d.Brand,
+		&d.Rating, &d.Name, &d.Q, &d.Facets, &d.Cursor, &d.Status, &d.Sort, &d.ViewerId, &d.ProductRequest.ShopId,
+	} {
+		*s = strings.Clone(*s)
+	}
+
+	return &d
+}
+
+// ExportColumns are the columns of a CSV or XLSX export, in the order of ExportRow.
+var ExportColumns = []string{
+	"id", "name", "description", "category_id", "category", "brand_id", "brand", "price", "min_price", "max_price",
+	"stock", "total_stock", "available_stock", "rating", "review_count", "image_url", "created_at",
+}
+
+// ExportRow returns the values of a product in the order of ExportColumns.
+func (p *ProductItem) ExportRow() []any {
+	return []any{
+		p.Id, p.Name, p.Description, p.CategoryId, p.Category, p.BrandId, p.Brand, p.Price, p.MinPrice, p.MaxPrice,
+		p.Stock, p.TotalStock, p.AvailableStock, p.Rating, p.ReviewCount, p.ImageUrl, p.CreatedAt,
+	}
+}
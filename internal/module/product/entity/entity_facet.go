@@ -0,0 +1,46 @@
+package entity
+
+import "strings"
+
+const (
+	FacetCategory = "category"
+	FacetBrand    = "brand"
+	FacetPrice    = "price"
+	FacetRating   = "rating"
+)
+
+// PriceFacetBoundaries splits the price facet into buckets: < 50, 50-100, 100-250, 250-500, 500-1000 and >= 1000.
+var PriceFacetBoundaries = []float64{50, 100, 250, 500, 1000}
+
+type FacetBucket struct {
+	Value string `json:"value" db:"value"`
+	Label string `json:"label,omitempty" db:"label"`
+	Count int    `json:"count" db:"count"`
+}
+
+type PriceFacetBucket struct {
+	Min   float64  `json:"min"`
+	Max   *float64 `json:"max"` // nil for the last, open ended bucket
+	Count int      `json:"count"`
+}
+
+type ProductFacets struct {
+	Category []FacetBucket      `json:"category,omitempty"`
+	Brand    []FacetBucket      `json:"brand,omitempty"`
+	Price    []PriceFacetBucket `json:"price,omitempty"`
+	Rating   []FacetBucket      `json:"rating,omitempty"`
+}
+
+// FacetList returns the requested facets, ex: "category,brand" => [category brand].
+func (r *ProductRequest) FacetList() []string {
+	if r.Facets == "" {
+		return nil
+	}
+
+	facets := strings.Split(r.Facets, ",")
+	for i := range facets {
+		facets[i] = strings.TrimSpace(facets[i])
+	}
+
+	return facets
+}
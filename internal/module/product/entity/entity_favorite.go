@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/types"
+)
+
+// FavoriteRequest adds a product to the wishlist of UserId or removes it, both are idempotent.
+type FavoriteRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	UserId    string `prop:"user_id" validate:"uuid" db:"user_id"`
+}
+
+type FavoriteResponse struct {
+	ProductId     string `json:"product_id" db:"product_id"`
+	IsFavorited   bool   `json:"is_favorited" db:"-"`
+	FavoriteCount int    `json:"favorite_count" db:"favorite_count"`
+}
+
+// FavoritesRequest lists the wishlist of UserId, the latest favorites first.
+type FavoritesRequest struct {
+	UserId   string `prop:"user_id" validate:"uuid" db:"user_id"`
+	Page     int    `query:"page" validate:"required,min=1"`
+	Paginate int    `query:"paginate" validate:"required,min=1,max=100"`
+}
+
+func (r *FavoritesRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type FavoriteItem struct {
+	ProductItem
+	FavoritedAt time.Time `json:"favorited_at" db:"favorited_at"`
+}
+
+type FavoritesResponse struct {
+	Items []FavoriteItem `json:"items"`
+	Meta  types.Meta     `json:"meta"`
+}
@@ -0,0 +1,59 @@
+package entity
+
+import "mime/multipart"
+
+type UploadImagesRequest struct {
+	ProductId string `params:"id" validate:"uuid"`
+	UserId    string `prop:"user_id" validate:"uuid"`
+
+	IsPrivate bool                    `form:"is_private"`
+	Images    []*multipart.FileHeader `form:"images" validate:"required,min=1,max=10"`
+}
+
+type UploadImagesResponse struct {
+	Items []ImageItem `json:"items"`
+}
+
+// CreateImageRequest is a single uploaded file that is ready to be recorded.
+type CreateImageRequest struct {
+	ProductId   string `db:"product_id"`
+	Filename    string `db:"filename"`
+	ContentType string `db:"content_type"`
+	Size        int64  `db:"size"`
+	IsPrivate   bool   `db:"is_private"`
+}
+
+type ImagesRequest struct {
+	UserId    string `prop:"user_id" validate:"omitempty,uuid"` // the shop owner also sees the products that are not active
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+}
+
+type ImageItem struct {
+	Id        string `json:"id" db:"id"`
+	Url       string `json:"url" db:"-"`
+	Position  int    `json:"position" db:"position"`
+	IsPrimary bool   `json:"is_primary" db:"is_primary"`
+	IsPrivate bool   `json:"is_private" db:"is_private"`
+	Filename  string `json:"-" db:"filename"`
+}
+
+type ReorderImagesRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	UserId    string `prop:"user_id" validate:"uuid"`
+
+	ImageIds []string `json:"image_ids" validate:"required,min=1,unique_in_slice,dive,uuid"`
+}
+
+type SetPrimaryImageRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	UserId    string `prop:"user_id" validate:"uuid"`
+
+	Id string `params:"image_id" validate:"uuid" db:"id"`
+}
+
+type DeleteImageRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	UserId    string `prop:"user_id" validate:"uuid"`
+
+	Id string `params:"image_id" validate:"uuid" db:"id"`
+}
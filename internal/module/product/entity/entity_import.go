@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Formats of an import file.
+const (
+	ImportCSV    = "csv"
+	ImportNDJSON = "ndjson"
+)
+
+// ImportProductsRequest is a file of products, one CreateProductRequest per CSV row or NDJSON line.
+// A CSV file starts with a header naming the columns after the json fields of CreateProductRequest.
+type ImportProductsRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Format string    `form:"format" validate:"required,oneof=csv ndjson"`
+	ShopId string    `form:"shop_id" validate:"omitempty,uuid"` // used for the rows without shop_id
+	File   io.Reader `json:"-" validate:"required"`
+}
+
+// ImportResult is the outcome of inserting one row, the row is rolled back when Err is set.
+type ImportResult struct {
+	Product *CreateProductResponse
+	Err     error
+}
+
+type ImportProductsResponse struct {
+	Total    int                 `json:"total"`
+	Accepted []ImportAcceptedRow `json:"accepted"`
+	Rejected []ImportRejectedRow `json:"rejected"`
+}
+
+type ImportAcceptedRow struct {
+	Line int    `json:"line"`
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type ImportRejectedRow struct {
+	Line   int `json:"line"`
+	Errors any `json:"errors"` // the same errors as the create product endpoint
+}
+
+// ImportFormat guesses the format of an import file from its extension, empty when it is unknown.
+func ImportFormat(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return ImportCSV
+	case ".ndjson", ".jsonl":
+		return ImportNDJSON
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,137 @@
+package entity
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/types"
+)
+
+var (
+	ErrScheduleOverlap    = errors.New("price schedule overlaps another schedule")
+	ErrSalePriceNotLower  = errors.New("sale price is not lower than the price")
+	ErrScheduleFinished   = errors.New("price schedule already ended or was cancelled")
+	ErrPriceTargetMissing = errors.New("product or variant of the price schedule not found")
+)
+
+// Statuses of a price schedule, a scheduled entry becomes active at starts_at and ended at ends_at.
+const (
+	ScheduleScheduled = "scheduled"
+	ScheduleActive    = "active"
+	ScheduleEnded     = "ended"
+	ScheduleCancelled = "cancelled"
+)
+
+// Reasons of a price history entry.
+const (
+	PriceCreated     = "created"
+	PriceUpdated     = "updated"
+	PriceSaleStarted = "sale_started"
+	PriceSaleEnded   = "sale_ended"
+)
+
+// EffectivePrice is the price a buyer pays, the sale price while a schedule is active.
+// A price lowered below the sale price during the schedule wins over it.
+func EffectivePrice(price float64, salePrice *float64) float64 {
+	if salePrice != nil {
+		return math.Min(price, *salePrice)
+	}
+
+	return price
+}
+
+// DiscountPercentage returns how much lower the effective price is than the original price, ex: 100 => 75 is 25.
+func DiscountPercentage(original, effective float64) float64 {
+	if original <= 0 || effective >= original {
+		return 0
+	}
+
+	return math.Round((original-effective)/original*10000) / 100
+}
+
+type CreatePriceScheduleRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	UserId    string `prop:"user_id" validate:"uuid" db:"created_by"`
+
+	VariantId *string   `json:"variant_id" validate:"omitempty,uuid" db:"variant_id"`
+	SalePrice float64   `json:"sale_price" validate:"required,gt=0" db:"sale_price"`
+	StartsAt  time.Time `json:"starts_at" validate:"required" db:"starts_at"`
+	EndsAt    time.Time `json:"ends_at" validate:"required,gtfield=StartsAt" db:"ends_at"`
+}
+
+type PriceSchedulesRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	UserId    string `prop:"user_id" validate:"uuid"`
+
+	Status   string `query:"status" validate:"omitempty,oneof=scheduled active ended cancelled" db:"status"`
+	Page     int    `query:"page" validate:"required,min=1"`
+	Paginate int    `query:"paginate" validate:"required,min=1,max=100"`
+}
+
+func (r *PriceSchedulesRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type PriceScheduleItem struct {
+	Id        string    `json:"id" db:"id"`
+	VariantId *string   `json:"variant_id" db:"variant_id"`
+	SalePrice float64   `json:"sale_price" db:"sale_price"`
+	StartsAt  time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt    time.Time `json:"ends_at" db:"ends_at"`
+	Status    string    `json:"status" db:"status"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type PriceSchedulesResponse struct {
+	Items []PriceScheduleItem `json:"items"`
+	Meta  types.Meta          `json:"meta"`
+}
+
+type CancelPriceScheduleRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	UserId    string `prop:"user_id" validate:"uuid"`
+
+	Id string `params:"schedule_id" validate:"uuid" db:"id"`
+}
+
+type PriceHistoryRequest struct {
+	UserId    string `prop:"user_id" validate:"omitempty,uuid"` // the shop owner also sees the products that are not active
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	VariantId string `query:"variant_id" validate:"omitempty,uuid" db:"variant_id"`
+	Page      int    `query:"page" validate:"required,min=1"`
+	Paginate  int    `query:"paginate" validate:"required,min=1,max=100"`
+}
+
+func (r *PriceHistoryRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type PriceHistoryItem struct {
+	Id             string    `json:"id" db:"id"`
+	VariantId      *string   `json:"variant_id" db:"variant_id"`
+	Price          float64   `json:"price" db:"price"`
+	SalePrice      *float64  `json:"sale_price" db:"sale_price"`
+	EffectivePrice float64   `json:"effective_price" db:"effective_price"`
+	Reason         string    `json:"reason" db:"reason"`
+	ScheduleId     *string   `json:"schedule_id" db:"schedule_id"`
+	ActorUserId    *string   `json:"actor_user_id" db:"actor_user_id"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+type PriceHistoryResponse struct {
+	Items []PriceHistoryItem `json:"items"`
+	Meta  types.Meta         `json:"meta"`
+}
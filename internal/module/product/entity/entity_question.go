@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"math"
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/types"
+)
+
+// AnswerRate returns the percentage of the questions of a product that are answered, ex: 75 when 3 of 4 are.
+func AnswerRate(questionCount, answeredCount int) float64 {
+	if questionCount == 0 {
+		return 0
+	}
+
+	return math.Round(float64(answeredCount)/float64(questionCount)*10000) / 100
+}
+
+type CreateQuestionRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	UserId    string `prop:"user_id" validate:"uuid" db:"user_id"`
+
+	Question string `json:"question" validate:"required,min=3,max=1000" db:"question"`
+}
+
+type CreateQuestionResponse struct {
+	Id string `json:"id" db:"id"`
+}
+
+// QuestionsRequest lists the answered questions of a product, the latest answers first.
+type QuestionsRequest struct {
+	UserId    string `prop:"user_id" validate:"omitempty,uuid"` // the shop owner also sees the products that are not active
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	Page      int    `query:"page" validate:"required,min=1"`
+	Paginate  int    `query:"paginate" validate:"required,min=1,max=100"`
+}
+
+func (r *QuestionsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type QuestionItem struct {
+	Id         string     `json:"id" db:"id"`
+	UserId     string     `json:"user_id" db:"user_id"`
+	Question   string     `json:"question" db:"question"`
+	Answer     *string    `json:"answer" db:"answer"`
+	AnsweredAt *time.Time `json:"answered_at" db:"answered_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+type QuestionsResponse struct {
+	Items []QuestionItem `json:"items"`
+	Meta  types.Meta     `json:"meta"`
+}
+
+// AnswerQuestionRequest answers a question or replaces its answer, only the owner of the shop can answer.
+type AnswerQuestionRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	UserId    string `prop:"user_id" validate:"uuid" db:"answered_by"`
+
+	Id     string `params:"question_id" validate:"uuid" db:"id"`
+	Answer string `json:"answer" validate:"required,max=2000" db:"answer"`
+}
+
+type AnswerQuestionResponse struct {
+	Id         string    `json:"id" db:"id"`
+	AnsweredAt time.Time `json:"answered_at" db:"answered_at"`
+}
+
+// DeleteQuestionRequest deletes a question asked by UserId, the owner of the shop can delete any question.
+type DeleteQuestionRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	UserId    string `prop:"user_id" validate:"uuid" db:"user_id"`
+
+	Id    string `params:"question_id" validate:"uuid" db:"id"`
+	Owner bool   `json:"-" validate:"-"` // set by the service
+}
+
+// UnansweredQuestionsRequest is the inbox of a shop, the oldest questions first.
+type UnansweredQuestionsRequest struct {
+	ShopId   string `params:"id" validate:"uuid" db:"shop_id"`
+	UserId   string `prop:"user_id" validate:"uuid"`
+	Page     int    `query:"page" validate:"required,min=1"`
+	Paginate int    `query:"paginate" validate:"required,min=1,max=100"`
+}
+
+func (r *UnansweredQuestionsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type UnansweredQuestionItem struct {
+	Id          string    `json:"id" db:"id"`
+	ProductId   string    `json:"product_id" db:"product_id"`
+	ProductName string    `json:"product_name" db:"product_name"`
+	UserId      string    `json:"user_id" db:"user_id"`
+	Question    string    `json:"question" db:"question"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+type UnansweredQuestionsResponse struct {
+	Items []UnansweredQuestionItem `json:"items"`
+	Meta  types.Meta               `json:"meta"`
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// Statuses of a stock reservation, only a reserved one holds stock.
+const (
+	ReservationReserved  = "reserved"
+	ReservationCommitted = "committed"
+	ReservationReleased  = "released"
+	ReservationExpired   = "expired"
+)
+
+const DefaultReservationTTL = 15 * time.Minute
+
+var (
+	ErrInsufficientStock    = errors.New("insufficient stock")
+	ErrStockItemNotFound    = errors.New("product or variant not found")
+	ErrVariantRequired      = errors.New("variant is required for products with variants")
+	ErrReservationExpired   = errors.New("reservation expired")
+	ErrReservationCommitted = errors.New("reservation already committed")
+)
+
+// ReservationItemError tells which item of a reservation failed.
+type ReservationItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *ReservationItemError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ReservationItemError) Unwrap() error {
+	return e.Err
+}
+
+type ReserveStockRequest struct {
+	UserId     string             `prop:"user_id" validate:"uuid" db:"user_id"` // owner of the reservation
+	OrderRef   string             `json:"order_ref" validate:"required,max=100" db:"order_ref"`
+	TtlSeconds int                `json:"ttl_seconds" validate:"omitempty,min=60,max=86400"` // DefaultReservationTTL when empty
+	Items      []ReserveStockItem `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+// TTL returns how long the stock is held before the reservation expires.
+func (r *ReserveStockRequest) TTL() time.Duration {
+	if r.TtlSeconds == 0 {
+		return DefaultReservationTTL
+	}
+
+	return time.Duration(r.TtlSeconds) * time.Second
+}
+
+type ReserveStockItem struct {
+	ProductId string  `json:"product_id" validate:"uuid" db:"product_id"`
+	VariantId *string `json:"variant_id" validate:"omitempty,uuid" db:"variant_id"` // required when the product has variants
+	Quantity  int     `json:"quantity" validate:"required,min=1" db:"quantity"`
+}
+
+type ReservationRequest struct {
+	OrderRef string `params:"order_ref" validate:"required,max=100" db:"order_ref"`
+	UserId   string `prop:"user_id" validate:"uuid" db:"user_id"` // must own the reservation, recorded as the actor of the sale on commit
+}
+
+type ReservationResponse struct {
+	Id        string             `json:"id" db:"id"`
+	UserId    string             `json:"-" db:"user_id"`
+	OrderRef  string             `json:"order_ref" db:"order_ref"`
+	Status    string             `json:"status" db:"status"`
+	ExpiresAt time.Time          `json:"expires_at" db:"expires_at"`
+	Items     []ReserveStockItem `json:"items" db:"-"`
+}
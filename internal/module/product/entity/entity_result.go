@@ -1,13 +1,29 @@
 package entity
 
 type GetProductResult struct {
-	Id          string  `db:"product_id"`
-	Name        string  `db:"product_name"`
-	Description string  `db:"description"`
-	Category    string  `db:"category"`
-	Price       float64 `db:"price"`
-	Stock       int     `db:"stock"`
-	ShopId      string  `db:"shop_id"`
-	ShopName    string  `db:"shop_name"`
-	ShopRating  int     `db:"shop_rating"`
+	Id             string   `db:"product_id"`
+	Name           string   `db:"product_name"`
+	Description    string   `db:"description"`
+	CategoryId     *string  `db:"category_id"`
+	Category       string   `db:"category"`
+	BrandId        *string  `db:"brand_id"`
+	Brand          *string  `db:"brand"`
+	Status         string   `db:"status"`
+	Price          float64  `db:"price"`
+	SalePrice      *float64 `db:"sale_price"`
+	Stock          int      `db:"stock"`
+	MinPrice       float64  `db:"min_price"`
+	MaxPrice       float64  `db:"max_price"`
+	TotalStock     int      `db:"total_stock"`
+	AvailableStock int      `db:"available_stock"`
+	Rating         float64  `db:"rating"`
+	ReviewCount    int      `db:"review_count"`
+	QuestionCount  int      `db:"question_count"`
+	AnsweredCount  int      `db:"answered_count"`
+	FavoriteCount  int      `db:"favorite_count"`
+	IsFavorited    bool     `db:"is_favorited"`
+	Version        int      `db:"version"`
+	ShopId         string   `db:"shop_id"`
+	ShopName       string   `db:"shop_name"`
+	ShopRating     float64  `db:"shop_rating"`
 }
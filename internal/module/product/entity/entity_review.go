@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/types"
+)
+
+type CreateReviewRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	UserId    string `prop:"user_id" validate:"uuid" db:"user_id"`
+
+	Rating int    `json:"rating" validate:"required,min=1,max=5" db:"rating"`
+	Text   string `json:"text" validate:"max=2000" db:"text"`
+}
+
+type CreateReviewResponse struct {
+	Id string `json:"id" db:"id"`
+}
+
+type ReviewsRequest struct {
+	UserId    string `prop:"user_id" validate:"omitempty,uuid"` // the shop owner also sees the products that are not active
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	Page      int    `query:"page" validate:"required,min=1"`
+	Paginate  int    `query:"paginate" validate:"required,min=1,max=100"`
+}
+
+func (r *ReviewsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type ReviewItem struct {
+	Id        string    `json:"id" db:"id"`
+	UserId    string    `json:"user_id" db:"user_id"`
+	Rating    int       `json:"rating" db:"rating"`
+	Text      string    `json:"text" db:"text"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type ReviewsResponse struct {
+	Items []ReviewItem `json:"items"`
+	Meta  types.Meta   `json:"meta"`
+}
+
+type UpdateReviewRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	UserId    string `prop:"user_id" validate:"uuid" db:"user_id"`
+
+	Id     string `params:"review_id" validate:"uuid" db:"id"`
+	Rating int    `json:"rating" validate:"required,min=1,max=5" db:"rating"`
+	Text   string `json:"text" validate:"max=2000" db:"text"`
+}
+
+type UpdateReviewResponse struct {
+	Id string `json:"id" db:"id"`
+}
+
+// DeleteReviewRequest can only delete the reviews written by UserId.
+type DeleteReviewRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	UserId    string `prop:"user_id" validate:"uuid" db:"user_id"`
+
+	Id string `params:"review_id" validate:"uuid" db:"id"`
+}
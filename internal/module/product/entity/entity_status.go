@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"errors"
+	"slices"
+)
+
+var ErrStatusTransition = errors.New("product status can not be changed")
+
+// Statuses of a product. Only the active products are shown to buyers, a draft has never been
+// published, an inactive product is hidden by its seller and an archived one is retired for good.
+const (
+	ProductDraft    = "draft"
+	ProductActive   = "active"
+	ProductInactive = "inactive"
+	ProductArchived = "archived"
+)
+
+// statusTransitions lists the statuses a product can move to from each status.
+var statusTransitions = map[string][]string{
+	ProductDraft:    {ProductActive, ProductArchived},
+	ProductActive:   {ProductInactive, ProductArchived},
+	ProductInactive: {ProductActive, ProductArchived},
+}
+
+// CanTransition tells whether a product can move from one status to another.
+func CanTransition(from, to string) bool {
+	return slices.Contains(statusTransitions[from], to)
+}
+
+// ChangeStatusRequest publishes, unpublishes or archives a product.
+type ChangeStatusRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id     string `params:"id" validate:"uuid" db:"id"`
+	Status string `json:"-" validate:"oneof=active inactive archived" db:"status"` // set by the handler from the action
+
+	IfMatch string `json:"-" validate:"-"`
+}
+
+type ChangeStatusResponse struct {
+	Id             string `json:"id" db:"id"`
+	Status         string `json:"status" db:"status"`
+	PreviousStatus string `json:"previous_status" db:"previous_status"`
+	Version        int    `json:"version" db:"version"`
+}
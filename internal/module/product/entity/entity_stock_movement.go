@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/types"
+)
+
+// Reasons of a stock movement, a sale takes stock out, a restock or a return puts it back
+// and an adjustment corrects it either way.
+const (
+	StockSale       = "sale"
+	StockRestock    = "restock"
+	StockAdjustment = "adjustment"
+	StockReturn     = "return"
+)
+
+// InitialStockReference is the reference of the restock recorded when a product or variant is created.
+const InitialStockReference = "initial stock"
+
+// CreateStockMovementRequest is a change of the stock of a product, or of one of its variants.
+// Every stock change is recorded as one, the stock columns only cache the sum of the deltas.
+type CreateStockMovementRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	UserId    string `prop:"user_id" validate:"omitempty,uuid" db:"actor_user_id"` // empty for system changes
+
+	VariantId *string `json:"variant_id" validate:"omitempty,uuid" db:"variant_id"`
+	Delta     int     `json:"delta" validate:"required" db:"delta"`
+	Reason    string  `json:"reason" validate:"required,oneof=sale restock adjustment return" db:"reason"`
+	Reference string  `json:"reference" validate:"max=100" db:"reference"` // ex: an order reference
+}
+
+type StockMovementsRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	UserId    string `prop:"user_id" validate:"uuid"`
+
+	VariantId string `query:"variant_id" validate:"omitempty,uuid" db:"variant_id"`
+	Reason    string `query:"reason" validate:"omitempty,oneof=sale restock adjustment return" db:"reason"`
+	Page      int    `query:"page" validate:"required,min=1"`
+	Paginate  int    `query:"paginate" validate:"required,min=1,max=100"`
+}
+
+func (r *StockMovementsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type StockMovementItem struct {
+	Id          string    `json:"id" db:"id"`
+	VariantId   *string   `json:"variant_id" db:"variant_id"`
+	Delta       int       `json:"delta" db:"delta"`
+	Balance     int       `json:"balance" db:"balance"` // stock after the movement
+	Reason      string    `json:"reason" db:"reason"`
+	ActorUserId *string   `json:"actor_user_id" db:"actor_user_id"`
+	Reference   *string   `json:"reference" db:"reference"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+type StockMovementsResponse struct {
+	Items []StockMovementItem `json:"items"`
+	Meta  types.Meta          `json:"meta"`
+}
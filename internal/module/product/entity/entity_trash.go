@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/types"
+)
+
+// TrashedProductsRequest lists the deleted products of a shop, they are kept in the trash
+// until they are restored, purged by the owner or purged after the retention period.
+type TrashedProductsRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	ShopId   string `query:"shop_id" validate:"uuid"`
+	Page     int    `query:"page" validate:"required,min=1"`
+	Paginate int    `query:"paginate" validate:"required,min=1,max=100"`
+}
+
+func (r *TrashedProductsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type TrashedProductItem struct {
+	Id        string    `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Status    string    `json:"status" db:"status"`
+	Price     float64   `json:"price" db:"price"`
+	DeletedAt time.Time `json:"deleted_at" db:"deleted_at"`
+}
+
+type TrashedProductsResponse struct {
+	Items []TrashedProductItem `json:"items"`
+	Meta  types.Meta           `json:"meta"`
+}
+
+// TrashedProductRequest restores or purges a deleted product.
+type TrashedProductRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id string `params:"id" validate:"uuid" db:"id"`
+}
+
+type RestoreProductResponse struct {
+	Id      string `json:"id" db:"id"`
+	ShopId  string `json:"shop_id" db:"shop_id"`
+	Name    string `json:"name" db:"name"`
+	Status  string `json:"status" db:"status"`
+	Version int    `json:"version" db:"version"`
+}
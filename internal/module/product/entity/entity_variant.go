@@ -0,0 +1,104 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+type VariantOption struct {
+	Name  string `json:"name" validate:"required,max=50"`
+	Value string `json:"value" validate:"required,max=50"`
+}
+
+// VariantOptions is stored as a JSONB array, ex: [{"name":"Color","value":"Red"}].
+type VariantOptions []VariantOption
+
+// Label joins the options for display, ex: "Color: Red / Size: M".
+func (o VariantOptions) Label() string {
+	parts := make([]string, 0, len(o))
+	for _, opt := range o {
+		parts = append(parts, opt.Name+": "+opt.Value)
+	}
+
+	return strings.Join(parts, " / ")
+}
+
+// Scan implements the sql.Scanner interface.
+func (o *VariantOptions) Scan(val interface{}) error {
+	if val == nil {
+		*o = VariantOptions{}
+		return nil
+	}
+
+	b, ok := val.([]byte)
+	if !ok {
+		return errors.New("invalid variant options type")
+	}
+
+	return json.Unmarshal(b, o)
+}
+
+// Value impl.
+func (o VariantOptions) Value() (driver.Value, error) {
+	if o == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(o)
+}
+
+type CreateVariantRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	UserId    string `prop:"user_id" validate:"uuid" db:"user_id"`
+
+	Sku     string         `json:"sku" validate:"required,max=100" db:"sku"`
+	Options VariantOptions `json:"options" validate:"required,min=1,dive" db:"options"`
+	Price   float64        `json:"price" validate:"required,gt=0" db:"price"`
+	Stock   int            `json:"stock" validate:"min=0" db:"stock"`
+}
+
+type CreateVariantResponse struct {
+	Id string `json:"id" db:"id"`
+}
+
+type VariantsRequest struct {
+	UserId    string `prop:"user_id" validate:"omitempty,uuid"` // the shop owner also sees the products that are not active
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+}
+
+type VariantItem struct {
+	Id             string         `json:"id" db:"id"`
+	Sku            string         `json:"sku" db:"sku"`
+	Label          string         `json:"label" db:"-"`
+	Options        VariantOptions `json:"options" db:"options"`
+	Price          float64        `json:"price" db:"price"`
+	SalePrice      *float64       `json:"sale_price" db:"sale_price"`
+	EffectivePrice float64        `json:"effective_price" db:"-"`
+	Discount       float64        `json:"discount_percentage" db:"-"`
+	Stock          int            `json:"stock" db:"stock"`
+	AvailableStock int            `json:"available_stock" db:"available_stock"`
+}
+
+type UpdateVariantRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	UserId    string `prop:"user_id" validate:"uuid" db:"user_id"`
+
+	Id      string         `params:"variant_id" validate:"uuid" db:"id"`
+	Sku     string         `json:"sku" validate:"required,max=100" db:"sku"`
+	Options VariantOptions `json:"options" validate:"required,min=1,dive" db:"options"`
+	Price   float64        `json:"price" validate:"required,gt=0" db:"price"`
+	Stock   int            `json:"stock" validate:"min=0" db:"stock"`
+}
+
+type UpdateVariantResponse struct {
+	Id string `json:"id" db:"id"`
+}
+
+type DeleteVariantRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	UserId    string `prop:"user_id" validate:"uuid" db:"user_id"`
+
+	Id string `params:"variant_id" validate:"uuid" db:"id"`
+}
@@ -0,0 +1,69 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/adapter"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/infrastructure/config"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/ports"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/repository"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/service"
+	shopRepository "github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/repository"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/scheduler"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/storage_manager"
+	"github.com/rs/zerolog/log"
+)
+
+type productJob struct {
+	service ports.ProductService
+}
+
+func NewProductJob() *productJob {
+	var (
+		job      = new(productJob)
+		repo     = repository.NewProductRepository(adapter.Adapters.ShopeefunPostgres)
+		shopRepo = shopRepository.NewShopRepository(adapter.Adapters.ShopeefunPostgres)
+		storage  = storage_manager.NewS3Storage(adapter.Adapters.ShopeefunStorage, config.Envs.ShopeefunStorage.Bucket)
+		service  = service.NewProductService(repo, shopRepo, storage, adapter.Adapters.Validator)
+	)
+	job.service = service
+
+	return job
+}
+
+func (j *productJob) Register(s *scheduler.Scheduler) {
+	s.Every("expire_stock_reservations", time.Minute, j.ExpireReservations)
+	s.Every("apply_price_schedules", time.Minute, j.ApplyPriceSchedules)
+	s.Every("purge_trashed_products", time.Hour, j.PurgeTrash)
+}
+
+func (j *productJob) ExpireReservations(ctx context.Context) error {
+	released, err := j.service.ExpireReservations(ctx)
+	if released > 0 {
+		log.Info().Int("released", released).Msg("job::ExpireReservations - Released expired reservations")
+	}
+
+	return err
+}
+
+func (j *productJob) ApplyPriceSchedules(ctx context.Context) error {
+	applied, err := j.service.ApplyPriceSchedules(ctx)
+	if applied > 0 {
+		log.Info().Int("applied", applied).Msg("job::ApplyPriceSchedules - Applied price schedules")
+	}
+
+	return err
+}
+
+// PurgeTrash deletes for good the products that stayed in the trash longer than the retention period.
+func (j *productJob) PurgeTrash(ctx context.Context) error {
+	before := time.Now().AddDate(0, 0, -config.Envs.Trash.RetentionDays)
+
+	purged, err := j.service.PurgeTrash(ctx, before)
+	if purged > 0 {
+		log.Info().Int("purged", purged).Msg("job::PurgeTrash - Purged trashed products")
+	}
+
+	return err
+}
@@ -1,17 +1,23 @@
 package rest
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/adapter"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/infrastructure/config"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/middleware"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/ports"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/repository"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/service"
+	shopRepository "github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/repository"
 	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/etag"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/patch"
 	"github.com/hilmiikhsan/shopeefun-product-service/pkg/response"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/storage_manager"
 	"github.com/rs/zerolog/log"
 )
 
@@ -21,9 +27,11 @@ type productHandler struct {
 
 func NewProductHandler() *productHandler {
 	var (
-		handler = new(productHandler)
-		repo    = repository.NewProductRepository(adapter.Adapters.ShopeefunPostgres)
-		service = service.NewProductService(repo)
+		handler  = new(productHandler)
+		repo     = repository.NewProductRepository(adapter.Adapters.ShopeefunPostgres)
+		shopRepo = shopRepository.NewShopRepository(adapter.Adapters.ShopeefunPostgres)
+		storage  = storage_manager.NewS3Storage(adapter.Adapters.ShopeefunStorage, config.Envs.ShopeefunStorage.Bucket)
+		service  = service.NewProductService(repo, shopRepo, storage, adapter.Adapters.Validator)
 	)
 	handler.service = service
 
@@ -32,10 +40,60 @@ func NewProductHandler() *productHandler {
 
 func (h *productHandler) Register(router fiber.Router) {
 	router.Post("/products", middleware.UserIdHeader, h.CreateProduct)
+	router.Post("/products/import", middleware.UserIdHeader, h.ImportProducts)
+	router.Get("/shops/:id/products/export", middleware.UserIdHeader, h.ExportProducts)
 	router.Get("/products/:id", middleware.UserIdHeader, h.GetProduct)
 	router.Get("/products", middleware.UserIdHeader, h.GetProducts)
+	router.Get("/shops/:id/products", middleware.OptionalUserIdHeader, h.GetShopProducts)
+	router.Get("/me/products", middleware.UserIdHeader, h.GetMyProducts)
 	router.Patch("/products/:id", middleware.UserIdHeader, h.UpdateProduct)
 	router.Delete("/products/:id", middleware.UserIdHeader, h.DeleteProduct)
+	router.Post("/products/:id/publish", middleware.UserIdHeader, h.ChangeStatus(entity.ProductActive))
+	router.Post("/products/:id/unpublish", middleware.UserIdHeader, h.ChangeStatus(entity.ProductInactive))
+	router.Post("/products/:id/archive", middleware.UserIdHeader, h.ChangeStatus(entity.ProductArchived))
+
+	router.Get("/trash/products", middleware.UserIdHeader, h.GetTrashedProducts)
+	router.Post("/trash/products/:id/restore", middleware.UserIdHeader, h.RestoreProduct)
+	router.Delete("/trash/products/:id", middleware.UserIdHeader, h.PurgeProduct)
+
+	router.Get("/products/:id/variants", middleware.UserIdHeader, h.GetVariants)
+	router.Post("/products/:id/variants", middleware.UserIdHeader, h.CreateVariant)
+	router.Patch("/products/:id/variants/:variant_id", middleware.UserIdHeader, h.UpdateVariant)
+	router.Delete("/products/:id/variants/:variant_id", middleware.UserIdHeader, h.DeleteVariant)
+
+	router.Get("/products/:id/images", middleware.UserIdHeader, h.GetImages)
+	router.Post("/products/:id/images", middleware.UserIdHeader, h.UploadImages)
+	router.Put("/products/:id/images/order", middleware.UserIdHeader, h.ReorderImages)
+	router.Put("/products/:id/images/:image_id/primary", middleware.UserIdHeader, h.SetPrimaryImage)
+	router.Delete("/products/:id/images/:image_id", middleware.UserIdHeader, h.DeleteImage)
+
+	router.Get("/products/:id/reviews", middleware.UserIdHeader, h.GetReviews)
+	router.Post("/products/:id/reviews", middleware.UserIdHeader, h.CreateReview)
+	router.Patch("/products/:id/reviews/:review_id", middleware.UserIdHeader, h.UpdateReview)
+	router.Delete("/products/:id/reviews/:review_id", middleware.UserIdHeader, h.DeleteReview)
+
+	router.Get("/products/:id/questions", middleware.OptionalUserIdHeader, h.GetQuestions)
+	router.Post("/products/:id/questions", middleware.UserIdHeader, h.CreateQuestion)
+	router.Put("/products/:id/questions/:question_id/answer", middleware.UserIdHeader, h.AnswerQuestion)
+	router.Delete("/products/:id/questions/:question_id", middleware.UserIdHeader, h.DeleteQuestion)
+	router.Get("/shops/:id/questions/unanswered", middleware.UserIdHeader, h.GetUnansweredQuestions)
+
+	router.Post("/products/:id/favorite", middleware.UserIdHeader, h.AddFavorite)
+	router.Delete("/products/:id/favorite", middleware.UserIdHeader, h.RemoveFavorite)
+	router.Get("/me/favorites", middleware.UserIdHeader, h.GetFavorites)
+
+	router.Get("/products/:id/stock-movements", middleware.UserIdHeader, h.GetStockMovements)
+	router.Post("/products/:id/stock-movements", middleware.UserIdHeader, h.CreateStockMovement)
+
+	router.Get("/products/:id/price-schedules", middleware.UserIdHeader, h.GetPriceSchedules)
+	router.Post("/products/:id/price-schedules", middleware.UserIdHeader, h.CreatePriceSchedule)
+	router.Delete("/products/:id/price-schedules/:schedule_id", middleware.UserIdHeader, h.CancelPriceSchedule)
+	router.Get("/products/:id/price-history", middleware.UserIdHeader, h.GetPriceHistory)
+
+	router.Post("/stock/reservations", middleware.UserIdHeader, h.ReserveStock)
+	router.Get("/stock/reservations/:order_ref", middleware.UserIdHeader, h.GetReservation)
+	router.Post("/stock/reservations/:order_ref/commit", middleware.UserIdHeader, h.CommitReservation)
+	router.Post("/stock/reservations/:order_ref/release", middleware.UserIdHeader, h.ReleaseReservation)
 }
 
 func (h *productHandler) CreateProduct(c *fiber.Ctx) error {
@@ -50,6 +108,8 @@ func (h *productHandler) CreateProduct(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
 	}
 
+	req.UserId = middleware.GetLocals(c).UserId
+
 	if err := validators.Validate(req); err != nil {
 		log.Warn().Err(err).Any("payload", req).Msg("handler::CreateProduct - Validate request body")
 		code, errs := errmsg.Errors(err, req)
@@ -73,6 +133,7 @@ func (h *productHandler) GetProduct(c *fiber.Ctx) error {
 	)
 
 	req.Id = c.Params("id")
+	req.UserId = middleware.GetLocals(c).UserId
 
 	fmt.Println("ID: ", req.Id)
 
@@ -88,6 +149,8 @@ func (h *productHandler) GetProduct(c *fiber.Ctx) error {
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
+	c.Set(fiber.HeaderETag, etag.Format(resp.Version))
+
 	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
 }
 
@@ -103,6 +166,7 @@ func (h *productHandler) GetProducts(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
 	}
 
+	req.ViewerId = middleware.GetLocals(c).UserId
 	req.SetDefault()
 
 	if err := validators.Validate(req); err != nil {
@@ -120,6 +184,67 @@ func (h *productHandler) GetProducts(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
 }
 
+func (h *productHandler) GetShopProducts(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.ProductRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetShopProducts - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ShopId = c.Params("id")
+	req.ViewerId = middleware.GetLocals(c).UserId
+	req.SetDefault()
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetShopProducts - Validate query params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetShopProducts(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) GetMyProducts(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.ProductRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetMyProducts - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ViewerId = middleware.GetLocals(c).UserId
+	req.SetDefault()
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetMyProducts - Validate query params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetMyProducts(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
 func (h *productHandler) UpdateProduct(c *fiber.Ctx) error {
 	var (
 		req        = new(entity.UpdateProductRequest)
@@ -132,7 +257,16 @@ func (h *productHandler) UpdateProduct(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
 	}
 
+	fields, err := patch.Parse(c.Body())
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateProduct - Parse merge patch")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.Fields = fields
 	req.Id = c.Params("id")
+	req.UserId = middleware.GetLocals(c).UserId
+	req.IfMatch = c.Get(fiber.HeaderIfMatch)
 
 	if err := validators.Validate(req); err != nil {
 		log.Warn().Err(err).Any("payload", req).Msg("handler::UpdateProduct - Validate request body")
@@ -141,11 +275,19 @@ func (h *productHandler) UpdateProduct(c *fiber.Ctx) error {
 	}
 
 	resp, err := h.service.UpdateProduct(ctx, req)
+
+	var stale *etag.StaleError
+	if errors.As(err, &stale) {
+		c.Set(fiber.HeaderETag, etag.Format(stale.Version))
+		return c.Status(fiber.StatusPreconditionFailed).JSON(response.Stale(stale))
+	}
 	if err != nil {
 		code, errs := errmsg.Errors[error](err)
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
+	c.Set(fiber.HeaderETag, etag.Format(resp.Version))
+
 	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
 }
 
@@ -157,6 +299,7 @@ func (h *productHandler) DeleteProduct(c *fiber.Ctx) error {
 	)
 
 	req.Id = c.Params("id")
+	req.UserId = middleware.GetLocals(c).UserId
 
 	if err := validators.Validate(req); err != nil {
 		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteProduct - Validate request body")
@@ -0,0 +1,74 @@
+package rest
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/adapter"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/middleware"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/response"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/xlsx"
+	"github.com/rs/zerolog/log"
+)
+
+// exportTimeout bounds the streaming of an export, which is no longer tied to the request once it starts.
+const exportTimeout = 30 * time.Minute
+
+var exportContentTypes = map[string]string{
+	entity.ExportCSV:    "text/csv; charset=utf-8",
+	entity.ExportNDJSON: "application/x-ndjson",
+	entity.ExportXLSX:   xlsx.ContentType,
+}
+
+func (h *productHandler) ExportProducts(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.ExportProductsRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::ExportProducts - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ShopId = c.Params("id")
+	req.UserId = middleware.GetLocals(c).UserId
+	req.ViewerId = req.UserId
+	req.SetDefault()
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::ExportProducts - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	// the export is streamed after the handler returned, when the fasthttp request may be reused
+	req = req.Detach()
+
+	export, err := h.service.ExportProducts(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	c.Attachment(fmt.Sprintf("products-%s.%s", req.ShopId, req.Format))
+	c.Set(fiber.HeaderContentType, exportContentTypes[req.Format])
+
+	// the status is already sent once streaming starts, a failed export ends as a truncated file
+	ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+		streamCtx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		defer cancel()
+
+		if err := export(streamCtx, w); err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("handler::ExportProducts - Failed to stream export")
+		}
+	})
+
+	return nil
+}
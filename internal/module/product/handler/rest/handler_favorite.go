@@ -0,0 +1,94 @@
+package rest
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/adapter"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/middleware"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/response"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *productHandler) AddFavorite(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.FavoriteRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+		l          = middleware.GetLocals(c)
+	)
+
+	req.ProductId = c.Params("id")
+	req.UserId = l.UserId
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::AddFavorite - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.AddFavorite(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) RemoveFavorite(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.FavoriteRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+		l          = middleware.GetLocals(c)
+	)
+
+	req.ProductId = c.Params("id")
+	req.UserId = l.UserId
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::RemoveFavorite - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.RemoveFavorite(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) GetFavorites(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.FavoritesRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+		l          = middleware.GetLocals(c)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetFavorites - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.SetDefault()
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetFavorites - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetFavorites(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
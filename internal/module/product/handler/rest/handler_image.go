@@ -0,0 +1,159 @@
+package rest
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/adapter"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/middleware"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/response"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *productHandler) UploadImages(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.UploadImagesRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::UploadImages - Parse multipart form")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ProductId = c.Params("id")
+	req.UserId = middleware.GetLocals(c).UserId
+	req.Images = form.File["images"]
+	if isPrivate := c.FormValue("is_private"); isPrivate != "" {
+		req.IsPrivate, err = strconv.ParseBool(isPrivate)
+		if err != nil {
+			log.Warn().Err(err).Msg("handler::UploadImages - Parse is_private")
+			return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+		}
+	}
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Str("product_id", req.ProductId).Msg("handler::UploadImages - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.UploadImages(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) GetImages(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.ImagesRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	req.ProductId = c.Params("id")
+	req.UserId = middleware.GetLocals(c).UserId
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetImages - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetImages(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) ReorderImages(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.ReorderImagesRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::ReorderImages - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ProductId = c.Params("id")
+	req.UserId = middleware.GetLocals(c).UserId
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::ReorderImages - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.ReorderImages(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) SetPrimaryImage(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.SetPrimaryImageRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	req.ProductId = c.Params("id")
+	req.UserId = middleware.GetLocals(c).UserId
+	req.Id = c.Params("image_id")
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::SetPrimaryImage - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.SetPrimaryImage(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
+
+func (h *productHandler) DeleteImage(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.DeleteImageRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	req.ProductId = c.Params("id")
+	req.UserId = middleware.GetLocals(c).UserId
+	req.Id = c.Params("image_id")
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteImage - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.DeleteImage(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
@@ -0,0 +1,51 @@
+package rest
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/adapter"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/middleware"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/response"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *productHandler) ImportProducts(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.ImportProductsRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::ImportProducts - Parse import file")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error("file is required"))
+	}
+
+	file, err := fh.Open()
+	if err != nil {
+		log.Error().Err(err).Msg("handler::ImportProducts - Open import file")
+		return c.Status(fiber.StatusInternalServerError).JSON(response.Error(err))
+	}
+	defer file.Close()
+
+	req.UserId = middleware.GetLocals(c).UserId
+	req.ShopId = c.FormValue("shop_id")
+	req.Format = c.FormValue("format", entity.ImportFormat(fh.Filename))
+	req.File = file
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::ImportProducts - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.ImportProducts(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
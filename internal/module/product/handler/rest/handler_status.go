@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/adapter"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/middleware"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/etag"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/response"
+	"github.com/rs/zerolog/log"
+)
+
+// ChangeStatus returns the handler of a status action, publish, unpublish or archive, moving a product to status.
+func (h *productHandler) ChangeStatus(status string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var (
+			req        = new(entity.ChangeStatusRequest)
+			ctx        = c.Context()
+			validators = adapter.Adapters.Validator
+		)
+
+		req.Id = c.Params("id")
+		req.UserId = middleware.GetLocals(c).UserId
+		req.Status = status
+		req.IfMatch = c.Get(fiber.HeaderIfMatch)
+
+		if err := validators.Validate(req); err != nil {
+			log.Warn().Err(err).Any("payload", req).Msg("handler::ChangeStatus - Validate request body")
+			code, errs := errmsg.Errors(err, req)
+			return c.Status(code).JSON(response.Error(errs))
+		}
+
+		resp, err := h.service.ChangeStatus(ctx, req)
+
+		var stale *etag.StaleError
+		if errors.As(err, &stale) {
+			c.Set(fiber.HeaderETag, etag.Format(stale.Version))
+			return c.Status(fiber.StatusPreconditionFailed).JSON(response.Stale(stale))
+		}
+		if err != nil {
+			code, errs := errmsg.Errors[error](err)
+			return c.Status(code).JSON(response.Error(errs))
+		}
+
+		c.Set(fiber.HeaderETag, etag.Format(resp.Version))
+
+		return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+	}
+}
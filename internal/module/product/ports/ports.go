@@ -2,6 +2,8 @@ package ports
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
 )
@@ -12,12 +14,130 @@ type ProductRepository interface {
 	GetProducts(ctx context.Context, req *entity.ProductRequest) (*entity.ProductsResponse, error)
 	UpdateProduct(ctx context.Context, req *entity.UpdateProductRequest) (*entity.UpdateProductResponse, error)
 	DeleteProduct(ctx context.Context, req *entity.DeleteProductRequest) error
+	ImportProducts(ctx context.Context, reqs []entity.CreateProductRequest) ([]entity.ImportResult, error)
+	ExportProducts(ctx context.Context, req *entity.ExportProductsRequest, fn func(item *entity.ProductItem) error) error
+	GetProductShop(ctx context.Context, productId string) (string, error)
+	ChangeStatus(ctx context.Context, req *entity.ChangeStatusRequest) (*entity.ChangeStatusResponse, error)
+
+	GetTrashedProductShop(ctx context.Context, productId string) (string, error)
+	GetTrashedProducts(ctx context.Context, req *entity.TrashedProductsRequest) (*entity.TrashedProductsResponse, error)
+	RestoreProduct(ctx context.Context, req *entity.TrashedProductRequest) (*entity.RestoreProductResponse, error)
+	PurgeProduct(ctx context.Context, req *entity.TrashedProductRequest) ([]entity.ImageItem, error)
+	PurgeExpiredProducts(ctx context.Context, before time.Time, limit int) (int, []entity.ImageItem, error)
+
+	CreateVariant(ctx context.Context, req *entity.CreateVariantRequest) (*entity.CreateVariantResponse, error)
+	GetVariants(ctx context.Context, req *entity.VariantsRequest) ([]entity.VariantItem, error)
+	UpdateVariant(ctx context.Context, req *entity.UpdateVariantRequest) (*entity.UpdateVariantResponse, error)
+	DeleteVariant(ctx context.Context, req *entity.DeleteVariantRequest) error
+
+	CreateImages(ctx context.Context, productId string, reqs []entity.CreateImageRequest) ([]entity.ImageItem, error)
+	GetImages(ctx context.Context, req *entity.ImagesRequest) ([]entity.ImageItem, error)
+	ReorderImages(ctx context.Context, req *entity.ReorderImagesRequest) error
+	SetPrimaryImage(ctx context.Context, req *entity.SetPrimaryImageRequest) error
+	DeleteImage(ctx context.Context, req *entity.DeleteImageRequest) (*entity.ImageItem, error)
+
+	CreateReview(ctx context.Context, req *entity.CreateReviewRequest) (*entity.CreateReviewResponse, error)
+	GetReviews(ctx context.Context, req *entity.ReviewsRequest) (*entity.ReviewsResponse, error)
+	UpdateReview(ctx context.Context, req *entity.UpdateReviewRequest) (*entity.UpdateReviewResponse, error)
+	DeleteReview(ctx context.Context, req *entity.DeleteReviewRequest) error
+
+	CreateQuestion(ctx context.Context, req *entity.CreateQuestionRequest) (*entity.CreateQuestionResponse, error)
+	GetQuestions(ctx context.Context, req *entity.QuestionsRequest) (*entity.QuestionsResponse, error)
+	AnswerQuestion(ctx context.Context, req *entity.AnswerQuestionRequest) (*entity.AnswerQuestionResponse, error)
+	DeleteQuestion(ctx context.Context, req *entity.DeleteQuestionRequest) error
+	GetUnansweredQuestions(ctx context.Context, req *entity.UnansweredQuestionsRequest) (*entity.UnansweredQuestionsResponse, error)
+
+	AddFavorite(ctx context.Context, req *entity.FavoriteRequest) (*entity.FavoriteResponse, error)
+	RemoveFavorite(ctx context.Context, req *entity.FavoriteRequest) (*entity.FavoriteResponse, error)
+	GetFavorites(ctx context.Context, req *entity.FavoritesRequest) (*entity.FavoritesResponse, error)
+
+	ReserveStock(ctx context.Context, req *entity.ReserveStockRequest) (*entity.ReservationResponse, error)
+	GetReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.ReservationResponse, error)
+	CommitReservation(ctx context.Context, req *entity.ReservationRequest) error
+	ReleaseReservation(ctx context.Context, req *entity.ReservationRequest) error
+	ExpireReservations(ctx context.Context, limit int) (int, error)
+
+	CreateStockMovement(ctx context.Context, req *entity.CreateStockMovementRequest) (*entity.StockMovementItem, error)
+	GetStockMovements(ctx context.Context, req *entity.StockMovementsRequest) (*entity.StockMovementsResponse, error)
+
+	CreatePriceSchedule(ctx context.Context, req *entity.CreatePriceScheduleRequest) (*entity.PriceScheduleItem, error)
+	GetPriceSchedules(ctx context.Context, req *entity.PriceSchedulesRequest) (*entity.PriceSchedulesResponse, error)
+	CancelPriceSchedule(ctx context.Context, req *entity.CancelPriceScheduleRequest) error
+	ApplyPriceSchedules(ctx context.Context, limit int) (int, error)
+	GetPriceHistory(ctx context.Context, req *entity.PriceHistoryRequest) (*entity.PriceHistoryResponse, error)
 }
 
 type ProductService interface {
 	CreateProduct(ctx context.Context, req *entity.CreateProductRequest) (*entity.CreateProductResponse, error)
 	GetProduct(ctx context.Context, req *entity.GetProductRequest) (*entity.GetProductResponse, error)
 	GetProducts(ctx context.Context, req *entity.ProductRequest) (*entity.ProductsResponse, error)
+	GetShopProducts(ctx context.Context, req *entity.ProductRequest) (*entity.ProductsResponse, error)
+	GetMyProducts(ctx context.Context, req *entity.ProductRequest) (*entity.ProductsResponse, error)
 	UpdateProduct(ctx context.Context, req *entity.UpdateProductRequest) (*entity.UpdateProductResponse, error)
 	DeleteProduct(ctx context.Context, req *entity.DeleteProductRequest) error
+	ImportProducts(ctx context.Context, req *entity.ImportProductsRequest) (*entity.ImportProductsResponse, error)
+	ExportProducts(ctx context.Context, req *entity.ExportProductsRequest) (func(ctx context.Context, w io.Writer) error, error)
+	ChangeStatus(ctx context.Context, req *entity.ChangeStatusRequest) (*entity.ChangeStatusResponse, error)
+
+	GetTrashedProducts(ctx context.Context, req *entity.TrashedProductsRequest) (*entity.TrashedProductsResponse, error)
+	RestoreProduct(ctx context.Context, req *entity.TrashedProductRequest) (*entity.RestoreProductResponse, error)
+	PurgeProduct(ctx context.Context, req *entity.TrashedProductRequest) error
+	PurgeTrash(ctx context.Context, before time.Time) (int, error)
+
+	CreateVariant(ctx context.Context, req *entity.CreateVariantRequest) (*entity.CreateVariantResponse, error)
+	GetVariants(ctx context.Context, req *entity.VariantsRequest) ([]entity.VariantItem, error)
+	UpdateVariant(ctx context.Context, req *entity.UpdateVariantRequest) (*entity.UpdateVariantResponse, error)
+	DeleteVariant(ctx context.Context, req *entity.DeleteVariantRequest) error
+
+	UploadImages(ctx context.Context, req *entity.UploadImagesRequest) (*entity.UploadImagesResponse, error)
+	GetImages(ctx context.Context, req *entity.ImagesRequest) ([]entity.ImageItem, error)
+	ReorderImages(ctx context.Context, req *entity.ReorderImagesRequest) ([]entity.ImageItem, error)
+	SetPrimaryImage(ctx context.Context, req *entity.SetPrimaryImageRequest) error
+	DeleteImage(ctx context.Context, req *entity.DeleteImageRequest) error
+
+	CreateReview(ctx context.Context, req *entity.CreateReviewRequest) (*entity.CreateReviewResponse, error)
+	GetReviews(ctx context.Context, req *entity.ReviewsRequest) (*entity.ReviewsResponse, error)
+	UpdateReview(ctx context.Context, req *entity.UpdateReviewRequest) (*entity.UpdateReviewResponse, error)
+	DeleteReview(ctx context.Context, req *entity.DeleteReviewRequest) error
+
+	CreateQuestion(ctx context.Context, req *entity.CreateQuestionRequest) (*entity.CreateQuestionResponse, error)
+	GetQuestions(ctx context.Context, req *entity.QuestionsRequest) (*entity.QuestionsResponse, error)
+	AnswerQuestion(ctx context.Context, req *entity.AnswerQuestionRequest) (*entity.AnswerQuestionResponse, error)
+	DeleteQuestion(ctx context.Context, req *entity.DeleteQuestionRequest) error
+	GetUnansweredQuestions(ctx context.Context, req *entity.UnansweredQuestionsRequest) (*entity.UnansweredQuestionsResponse, error)
+
+	AddFavorite(ctx context.Context, req *entity.FavoriteRequest) (*entity.FavoriteResponse, error)
+	RemoveFavorite(ctx context.Context, req *entity.FavoriteRequest) (*entity.FavoriteResponse, error)
+	GetFavorites(ctx context.Context, req *entity.FavoritesRequest) (*entity.FavoritesResponse, error)
+
+	ReserveStock(ctx context.Context, req *entity.ReserveStockRequest) (*entity.ReservationResponse, error)
+	GetReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.ReservationResponse, error)
+	CommitReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.ReservationResponse, error)
+	ReleaseReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.ReservationResponse, error)
+	ExpireReservations(ctx context.Context) (int, error)
+
+	CreateStockMovement(ctx context.Context, req *entity.CreateStockMovementRequest) (*entity.StockMovementItem, error)
+	GetStockMovements(ctx context.Context, req *entity.StockMovementsRequest) (*entity.StockMovementsResponse, error)
+
+	CreatePriceSchedule(ctx context.Context, req *entity.CreatePriceScheduleRequest) (*entity.PriceScheduleItem, error)
+	GetPriceSchedules(ctx context.Context, req *entity.PriceSchedulesRequest) (*entity.PriceSchedulesResponse, error)
+	CancelPriceSchedule(ctx context.Context, req *entity.CancelPriceScheduleRequest) error
+	ApplyPriceSchedules(ctx context.Context) (int, error)
+	GetPriceHistory(ctx context.Context, req *entity.PriceHistoryRequest) (*entity.PriceHistoryResponse, error)
+}
+
+// ShopRepository reads the owners of the shops, implemented by the shop repository.
+type ShopRepository interface {
+	GetShopOwner(ctx context.Context, shopId string) (string, error)
+}
+
+// Validator validates the rows of an import file, implemented by validator.Validator.
+type Validator interface {
+	Validate(i any) error
+}
+
+// ProductStorage stores uploaded product files, implemented by storage_manager.S3Storage.
+type ProductStorage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
 }
@@ -1,15 +1,64 @@
 package repository
 
 const (
+	// queryInsertProduct starts without stock, the initial stock is recorded as a stock movement.
 	queryInsertProduct = `
 		INSERT INTO products (
 			shop_id, 
 			name, 
 			description, 
+			category_id,
 			category,
+			brand_id,
+			brand,
 			price,
-			stock
-		) VALUES (?, ?, ?, ?, ?, ?) RETURNING id, name
+			stock,
+			min_price,
+			max_price,
+			total_stock,
+			available_stock,
+			status
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, 0, ?, ?, 0, 0, ?) RETURNING id, name, status
+	`
+
+	queryGetCategoryName = `
+		SELECT name
+		FROM categories
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	queryGetBrandName = `
+		SELECT name
+		FROM brands
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	queryBrandIdsBySlugs = `
+		SELECT id FROM brands WHERE slug = ANY(CAST(:brands AS TEXT[])) AND deleted_at IS NULL
+	`
+
+	// queryCategoryTreeBySlug and queryCategoryTreeById select the ids of a category and all of its descendants.
+	queryCategoryTreeBySlug = `
+		WITH RECURSIVE tree AS (
+			SELECT id FROM categories WHERE slug = :category AND deleted_at IS NULL
+			UNION ALL
+			SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id WHERE c.deleted_at IS NULL
+		)
+		SELECT id FROM tree
+	`
+
+	queryCategoryTreeById = `
+		WITH RECURSIVE tree AS (
+			SELECT id FROM categories WHERE id = :category_id AND deleted_at IS NULL
+			UNION ALL
+			SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id WHERE c.deleted_at IS NULL
+		)
+		SELECT id FROM tree
+	`
+
+	// queryViewerShopIds selects the shops of the user listing the products, none for an anonymous buyer.
+	queryViewerShopIds = `
+		SELECT id FROM shops WHERE user_id = CAST(NULLIF(:viewer_id, '') AS UUID)
 	`
 
 	queryGetProductById = `
@@ -17,47 +66,128 @@ const (
 			p.id as product_id,
 			p.name as product_name,
 			p.description,
+			p.category_id,
 			p.category,
+			p.brand_id,
+			p.brand,
+			p.status,
 			p.price,
+			p.sale_price,
 			p.stock,
+			p.min_price,
+			p.max_price,
+			p.total_stock,
+			p.available_stock,
+			p.rating,
+			p.review_count,
+			p.question_count,
+			p.answered_count,
+			p.favorite_count,
+			EXISTS (
+				SELECT 1
+				FROM product_favorites f
+				WHERE f.product_id = p.id AND f.user_id = CAST(NULLIF(?, '') AS UUID)
+			) as is_favorited,
+			p.version,
 			s.id as shop_id,
 			s.name as shop_name,
 			s.rating as shop_rating
 		FROM products p
 		JOIN shops s ON p.shop_id = s.id
-		WHERE p.id = ?
+		WHERE
+			p.id = ?
+			AND p.deleted_at IS NULL
+			AND s.deleted_at IS NULL
+			AND (p.status = 'active' OR s.user_id = CAST(NULLIF(?, '') AS UUID))
 	`
 
+	// queryGetProducts is completed with the total column (queryTotalColumn or queryNoTotalColumn)
+	// and the search columns (querySearchColumns or queryNoSearchColumns).
 	queryGetProducts = `
 		SELECT
-			COUNT(id) OVER() as total_data,
+			%s,
 			id,
 			name,
 			description,
+			category_id,
 			category,
+			brand_id,
+			brand,
+			status,
 			price,
 			stock,
-			rating
+			min_price,
+			max_price,
+			total_stock,
+			available_stock,
+			rating,
+			review_count,
+			favorite_count,
+			EXISTS (
+				SELECT 1
+				FROM product_favorites f
+				WHERE f.product_id = products.id AND f.user_id = CAST(NULLIF(:viewer_id, '') AS UUID)
+			) as is_favorited,
+			created_at,
+			sale_price,
+			reserved_stock,
+			version,
+			updated_at,
+			COALESCE(pi.image_filename, '') as image_filename,
+			COALESCE(pi.image_is_private, false) as image_is_private,
+			%s
 		FROM products
+		LEFT JOIN LATERAL (
+			SELECT
+				filename as image_filename,
+				is_private as image_is_private
+			FROM product_images
+			WHERE product_id = products.id AND is_primary
+		) pi ON true
 		WHERE deleted_at IS NULL
 	`
 
+	queryTotalColumn = `COUNT(id) OVER() as total_data`
+
+	// queryNoTotalColumn skips counting for keyset pages, the window would only count the rows after the cursor
+	queryNoTotalColumn = `0 as total_data`
+
+	// querySearchColumns ranks and highlights a product against the "q" named param,
+	// it replaces queryNoSearchColumns in queryGetProducts when a search term is given.
+	querySearchColumns = `
+			ts_rank(search_vector, websearch_to_tsquery('simple', :q)) as rank,
+			ts_headline('simple', name, websearch_to_tsquery('simple', :q),
+				'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') as name_highlight,
+			ts_headline('simple', COALESCE(description, ''), websearch_to_tsquery('simple', :q),
+				'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2') as description_highlight`
+
+	queryNoSearchColumns = `
+			0 as rank,
+			'' as name_highlight,
+			'' as description_highlight`
+
+	// queryUpdateProduct sets only the columns of the patch, %s is the list of "column = :column," assignments.
 	queryUpdateProduct = `
 		UPDATE products
 		SET
-			name = ?,
-			description = ?,
-			category = ?,
-			price = ?,
-			stock = ?
-		WHERE id = ? AND shop_id = ?
-		RETURNING id
+			%s
+			version = version + 1,
+			updated_at = NOW()
+		WHERE id = :id AND shop_id = :shop_id
+		RETURNING id, name, version
+	`
+
+	// queryGetProductVersion reads the version of a product locked by the transaction.
+	queryGetProductVersion = `
+		SELECT version
+		FROM products
+		WHERE id = ?
 	`
 
 	queryDeleteProduct = `
 		UPDATE products
 		SET
-			delete_at = NOW()
-		WHERE id = ? AND shop_id = ?
+			deleted_at = NOW()
+		WHERE id = ? AND shop_id = ? AND deleted_at IS NULL
 	`
 )
@@ -0,0 +1,60 @@
+package repository
+
+// facet queries are completed with the listing conditions, see productsFilter.
+const (
+	// queryFacetCategory and queryFacetBrand filter in a subquery, the listing conditions use columns
+	// (name, slug) that categories and brands also have.
+	queryFacetCategory = `
+		SELECT
+			c.slug as value,
+			c.name as label,
+			COUNT(p.id) as count
+		FROM (
+			SELECT id, category_id
+			FROM products
+			WHERE deleted_at IS NULL %s
+		) p
+		JOIN categories c ON c.id = p.category_id
+		GROUP BY c.id, c.slug, c.name
+		ORDER BY count DESC, value
+		LIMIT 20
+	`
+
+	queryFacetBrand = `
+		SELECT
+			b.slug as value,
+			b.name as label,
+			COUNT(p.id) as count
+		FROM (
+			SELECT id, brand_id
+			FROM products
+			WHERE deleted_at IS NULL %s
+		) p
+		JOIN brands b ON b.id = p.brand_id
+		GROUP BY b.id, b.slug, b.name
+		ORDER BY count DESC, value
+		LIMIT 20
+	`
+
+	// queryFacetRating counts the reviewed products by whole stars, ex: "4" counts ratings from 4.00 to 4.99.
+	queryFacetRating = `
+		SELECT
+			CAST(FLOOR(rating) AS TEXT) as value,
+			COUNT(id) as count
+		FROM products
+		WHERE deleted_at IS NULL AND review_count > 0 %s
+		GROUP BY FLOOR(rating)
+		ORDER BY FLOOR(rating) DESC
+	`
+
+	// queryFacetPrice buckets the lowest price of each product, the first %s is the boundaries array.
+	queryFacetPrice = `
+		SELECT
+			width_bucket(min_price, ARRAY[%s]) as bucket,
+			COUNT(id) as count
+		FROM products
+		WHERE deleted_at IS NULL %s
+		GROUP BY bucket
+		ORDER BY bucket
+	`
+)
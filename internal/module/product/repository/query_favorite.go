@@ -0,0 +1,67 @@
+package repository
+
+const (
+	queryInsertFavorite = `
+		INSERT INTO product_favorites (user_id, product_id)
+		VALUES (?, ?)
+		ON CONFLICT (user_id, product_id) DO NOTHING
+	`
+
+	queryDeleteFavorite = `
+		DELETE FROM product_favorites
+		WHERE user_id = ? AND product_id = ?
+	`
+
+	// queryRefreshFavoriteCount recomputes the favorite count of a product and returns it.
+	queryRefreshFavoriteCount = `
+		UPDATE products
+		SET
+			favorite_count = (
+				SELECT COUNT(user_id)
+				FROM product_favorites
+				WHERE product_id = ?
+			)
+		WHERE id = ?
+		RETURNING favorite_count
+	`
+
+	// queryGetFavorites lists the favorites of a user with the products that are not deleted,
+	// a product that is no longer active is listed with its status.
+	queryGetFavorites = `
+		SELECT
+			COUNT(f.product_id) OVER() as total_data,
+			f.created_at as favorited_at,
+			p.id,
+			p.name,
+			p.description,
+			p.category_id,
+			p.category,
+			p.brand_id,
+			p.brand,
+			p.status,
+			p.price,
+			p.stock,
+			p.min_price,
+			p.max_price,
+			p.total_stock,
+			p.available_stock,
+			p.rating,
+			p.review_count,
+			p.favorite_count,
+			p.created_at,
+			COALESCE(pi.image_filename, '') as image_filename,
+			COALESCE(pi.image_is_private, false) as image_is_private
+		FROM product_favorites f
+		JOIN products p ON p.id = f.product_id
+		LEFT JOIN LATERAL (
+			SELECT
+				filename as image_filename,
+				is_private as image_is_private
+			FROM product_images
+			WHERE product_id = p.id AND is_primary
+		) pi ON true
+		WHERE f.user_id = ? AND p.deleted_at IS NULL
+		ORDER BY f.created_at DESC, f.product_id
+		LIMIT ? OFFSET ?
+	`
+)
@@ -0,0 +1,88 @@
+package repository
+
+const (
+	queryLockProduct = `
+		SELECT id
+		FROM products
+		WHERE id = ? AND deleted_at IS NULL
+		FOR UPDATE
+	`
+
+	// queryInsertImage appends the image after the last position, the first image of a product becomes primary.
+	queryInsertImage = `
+		INSERT INTO product_images (
+			product_id,
+			filename,
+			content_type,
+			size,
+			position,
+			is_primary,
+			is_private
+		)
+		SELECT
+			?, ?, ?, ?,
+			COALESCE(MAX(position), 0) + 1,
+			NOT COALESCE(BOOL_OR(is_primary), false),
+			?
+		FROM product_images
+		WHERE product_id = ?
+		RETURNING id, filename, position, is_primary, is_private
+	`
+
+	queryGetImagesByProductId = `
+		SELECT
+			id,
+			filename,
+			position,
+			is_primary,
+			is_private
+		FROM product_images
+		WHERE product_id = ?
+		ORDER BY position, id
+	`
+
+	queryReorderImages = `
+		UPDATE product_images i
+		SET
+			position = x.position,
+			updated_at = NOW()
+		FROM unnest(?::uuid[]) WITH ORDINALITY AS x(id, position)
+		WHERE i.id = x.id AND i.product_id = ?
+	`
+
+	queryUnsetPrimaryImage = `
+		UPDATE product_images
+		SET
+			is_primary = false,
+			updated_at = NOW()
+		WHERE product_id = ? AND is_primary
+	`
+
+	querySetPrimaryImage = `
+		UPDATE product_images
+		SET
+			is_primary = true,
+			updated_at = NOW()
+		WHERE id = ? AND product_id = ?
+	`
+
+	queryDeleteImage = `
+		DELETE FROM product_images
+		WHERE id = ? AND product_id = ?
+		RETURNING id, filename, position, is_primary, is_private
+	`
+
+	queryPromoteFirstImage = `
+		UPDATE product_images
+		SET
+			is_primary = true,
+			updated_at = NOW()
+		WHERE id = (
+			SELECT id
+			FROM product_images
+			WHERE product_id = ?
+			ORDER BY position, id
+			LIMIT 1
+		)
+	`
+)
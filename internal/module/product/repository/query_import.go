@@ -0,0 +1,7 @@
+package repository
+
+const (
+	querySavepointImportRow = `SAVEPOINT import_row`
+	queryRollbackImportRow  = `ROLLBACK TO SAVEPOINT import_row`
+	queryReleaseImportRow   = `RELEASE SAVEPOINT import_row`
+)
@@ -0,0 +1,21 @@
+package repository
+
+const (
+	// queryInsertProductEvents writes an event per product holding its row as it is after the write.
+	queryInsertProductEvents = `
+		INSERT INTO outbox_events (aggregate_type, aggregate_id, event_type, payload)
+		SELECT CAST(? AS VARCHAR), p.id, CAST(? AS VARCHAR), to_jsonb(p) - 'search_vector'
+		FROM products p
+		WHERE p.id = ANY(CAST(? AS UUID[]))
+		ORDER BY p.id
+	`
+
+	// queryInsertStockEvent writes the stock movement with the shop of its product, the webhooks of the shop are pushed the event.
+	queryInsertStockEvent = `
+		INSERT INTO outbox_events (aggregate_type, aggregate_id, event_type, payload)
+		SELECT CAST(? AS VARCHAR), m.product_id, CAST(? AS VARCHAR), to_jsonb(m) || jsonb_build_object('shop_id', p.shop_id)
+		FROM stock_movements m
+		JOIN products p ON p.id = m.product_id
+		WHERE m.id = ?
+	`
+)
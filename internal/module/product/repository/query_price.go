@@ -0,0 +1,175 @@
+package repository
+
+const (
+	// queryLockProductPrice and queryLockVariantPrice lock the row holding the price of a schedule.
+	queryLockProductPrice = `
+		SELECT price
+		FROM products
+		WHERE id = ? AND deleted_at IS NULL
+		FOR UPDATE
+	`
+
+	queryLockVariantPrice = `
+		SELECT price
+		FROM product_variants
+		WHERE id = ? AND product_id = ? AND deleted_at IS NULL
+		FOR UPDATE
+	`
+
+	querySetProductSalePrice = `
+		UPDATE products
+		SET
+			sale_price = ?
+		WHERE id = ?
+	`
+
+	querySetVariantSalePrice = `
+		UPDATE product_variants
+		SET
+			sale_price = ?
+		WHERE id = ?
+	`
+
+	// queryInsertProductPriceHistory and queryInsertVariantPriceHistory copy the current price of a product
+	// or variant into its history, unless it is the same as the latest entry.
+	queryInsertProductPriceHistory = `
+		INSERT INTO price_history (product_id, variant_id, price, sale_price, reason, schedule_id, actor_user_id)
+		SELECT p.id, NULL, p.price, p.sale_price, ?, CAST(NULLIF(?, '') AS UUID), CAST(NULLIF(?, '') AS UUID)
+		FROM products p
+		WHERE p.id = ?
+		AND NOT EXISTS (
+			SELECT 1
+			FROM (
+				SELECT price, sale_price
+				FROM price_history
+				WHERE product_id = p.id AND variant_id IS NULL
+				ORDER BY created_at DESC, id DESC
+				LIMIT 1
+			) latest
+			WHERE latest.price = p.price AND latest.sale_price IS NOT DISTINCT FROM p.sale_price
+		)
+	`
+
+	queryInsertVariantPriceHistory = `
+		INSERT INTO price_history (product_id, variant_id, price, sale_price, reason, schedule_id, actor_user_id)
+		SELECT v.product_id, v.id, v.price, v.sale_price, ?, CAST(NULLIF(?, '') AS UUID), CAST(NULLIF(?, '') AS UUID)
+		FROM product_variants v
+		WHERE v.id = ?
+		AND NOT EXISTS (
+			SELECT 1
+			FROM (
+				SELECT price, sale_price
+				FROM price_history
+				WHERE variant_id = v.id
+				ORDER BY created_at DESC, id DESC
+				LIMIT 1
+			) latest
+			WHERE latest.price = v.price AND latest.sale_price IS NOT DISTINCT FROM v.sale_price
+		)
+	`
+
+	// queryGetPriceHistory uses the lower of both prices as the effective price, LEAST skips a NULL sale price.
+	queryGetPriceHistory = `
+		SELECT
+			COUNT(id) OVER() as total_data,
+			id,
+			variant_id,
+			price,
+			sale_price,
+			LEAST(price, sale_price) as effective_price,
+			reason,
+			schedule_id,
+			actor_user_id,
+			created_at
+		FROM price_history
+		WHERE
+			product_id = ?
+			AND (? = '' OR variant_id = CAST(NULLIF(?, '') AS UUID))
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	// queryPriceScheduleOverlaps checks for a pending schedule of the same product or variant sharing part of the period.
+	queryPriceScheduleOverlaps = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM price_schedules
+			WHERE
+				product_id = ?
+				AND variant_id IS NOT DISTINCT FROM CAST(? AS UUID)
+				AND status IN ('scheduled', 'active')
+				AND starts_at < ?
+				AND ends_at > ?
+		)
+	`
+
+	queryInsertPriceSchedule = `
+		INSERT INTO price_schedules (
+			product_id,
+			variant_id,
+			sale_price,
+			starts_at,
+			ends_at,
+			created_by
+		) VALUES (?, ?, ?, ?, ?, CAST(NULLIF(?, '') AS UUID))
+		RETURNING id, variant_id, sale_price, starts_at, ends_at, status, created_at
+	`
+
+	queryGetPriceSchedules = `
+		SELECT
+			COUNT(id) OVER() as total_data,
+			id,
+			variant_id,
+			sale_price,
+			starts_at,
+			ends_at,
+			status,
+			created_at
+		FROM price_schedules
+		WHERE
+			product_id = ?
+			AND (? = '' OR status = ?)
+		ORDER BY starts_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	queryLockPriceSchedule = `
+		SELECT
+			id,
+			product_id,
+			variant_id,
+			sale_price,
+			status,
+			ends_at <= NOW() as expired
+		FROM price_schedules
+		WHERE id = ? AND product_id = ?
+		FOR UPDATE
+	`
+
+	// queryLockDuePriceSchedules locks the schedules to end and to start, ending ones first so that
+	// a schedule starting when the previous one ends never overlaps it.
+	queryLockDuePriceSchedules = `
+		SELECT
+			id,
+			product_id,
+			variant_id,
+			sale_price,
+			status,
+			ends_at <= NOW() as expired
+		FROM price_schedules
+		WHERE
+			(status = 'active' AND ends_at <= NOW())
+			OR (status = 'scheduled' AND starts_at <= NOW())
+		ORDER BY CASE WHEN status = 'active' THEN 0 ELSE 1 END, starts_at
+		LIMIT ?
+		FOR UPDATE SKIP LOCKED
+	`
+
+	queryUpdatePriceScheduleStatus = `
+		UPDATE price_schedules
+		SET
+			status = ?,
+			updated_at = NOW()
+		WHERE id = ?
+	`
+)
@@ -0,0 +1,84 @@
+package repository
+
+const (
+	queryInsertQuestion = `
+		INSERT INTO product_questions (
+			product_id,
+			user_id,
+			question
+		)
+		SELECT ?, ?, ?
+		WHERE EXISTS (
+			SELECT 1 FROM products WHERE id = ? AND deleted_at IS NULL
+		)
+		RETURNING id
+	`
+
+	queryGetAnsweredQuestions = `
+		SELECT
+			COUNT(id) OVER() as total_data,
+			id,
+			user_id,
+			question,
+			answer,
+			answered_at,
+			created_at
+		FROM product_questions
+		WHERE product_id = ? AND answer IS NOT NULL AND deleted_at IS NULL
+		ORDER BY answered_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	// queryAnswerQuestion keeps the time of the first answer when the answer is replaced.
+	queryAnswerQuestion = `
+		UPDATE product_questions
+		SET
+			answer = ?,
+			answered_by = ?,
+			answered_at = COALESCE(answered_at, NOW()),
+			updated_at = NOW()
+		WHERE id = ? AND product_id = ? AND deleted_at IS NULL
+		RETURNING id, answered_at
+	`
+
+	// querySoftDeleteQuestion deletes any question of the product when the user is empty.
+	querySoftDeleteQuestion = `
+		UPDATE product_questions
+		SET
+			deleted_at = NOW()
+		WHERE id = ? AND product_id = ? AND deleted_at IS NULL
+			AND (? = '' OR user_id = CAST(NULLIF(?, '') AS UUID))
+	`
+
+	queryGetUnansweredQuestions = `
+		SELECT
+			COUNT(q.id) OVER() as total_data,
+			q.id,
+			q.product_id,
+			p.name as product_name,
+			q.user_id,
+			q.question,
+			q.created_at
+		FROM product_questions q
+		JOIN products p ON p.id = q.product_id
+		WHERE p.shop_id = ? AND p.deleted_at IS NULL AND q.answer IS NULL AND q.deleted_at IS NULL
+		ORDER BY q.created_at, q.id
+		LIMIT ? OFFSET ?
+	`
+
+	// queryRefreshQuestionCount recomputes the question and answered counts of a product.
+	queryRefreshQuestionCount = `
+		UPDATE products p
+		SET
+			question_count = q.question_count,
+			answered_count = q.answered_count
+		FROM (
+			SELECT
+				COUNT(id) as question_count,
+				COUNT(id) FILTER (WHERE answer IS NOT NULL) as answered_count
+			FROM product_questions
+			WHERE product_id = ? AND deleted_at IS NULL
+		) q
+		WHERE p.id = ?
+	`
+)
@@ -0,0 +1,140 @@
+package repository
+
+const (
+	queryInsertReservation = `
+		INSERT INTO stock_reservations (
+			user_id,
+			order_ref,
+			expires_at
+		) VALUES (?, ?, NOW() + CAST(? AS INTERVAL)) RETURNING id
+	`
+
+	queryInsertReservationItem = `
+		INSERT INTO stock_reservation_items (
+			reservation_id,
+			product_id,
+			variant_id,
+			quantity
+		) VALUES (?, ?, ?, ?)
+	`
+
+	// queryGetReservationByOrderRef returns the live reservation of an order, or its latest one.
+	queryGetReservationByOrderRef = `
+		SELECT
+			id,
+			COALESCE(CAST(user_id AS TEXT), '') as user_id,
+			order_ref,
+			status,
+			expires_at
+		FROM stock_reservations
+		WHERE order_ref = ?
+		ORDER BY status IN ('reserved', 'committed') DESC, created_at DESC
+		LIMIT 1
+	`
+
+	// queryLockLiveReservation only locks a reservation of the given user, another one is not found.
+	queryLockLiveReservation = `
+		SELECT
+			id,
+			user_id,
+			order_ref,
+			status,
+			expires_at
+		FROM stock_reservations
+		WHERE order_ref = ? AND user_id = ? AND status IN ('reserved', 'committed')
+		FOR UPDATE
+	`
+
+	// queryLockExpiredReservations skips the reservations being committed or released meanwhile.
+	queryLockExpiredReservations = `
+		SELECT id
+		FROM stock_reservations
+		WHERE status = 'reserved' AND expires_at < NOW()
+		ORDER BY expires_at
+		LIMIT ?
+		FOR UPDATE SKIP LOCKED
+	`
+
+	queryUpdateReservationStatus = `
+		UPDATE stock_reservations
+		SET
+			status = ?,
+			updated_at = NOW()
+		WHERE id = ?
+	`
+
+	// queryGetReservationItems orders the items like the rows are locked when reserving, to avoid deadlocks.
+	queryGetReservationItems = `
+		SELECT
+			product_id,
+			variant_id,
+			quantity
+		FROM stock_reservation_items
+		WHERE reservation_id = ?
+		ORDER BY product_id, variant_id NULLS FIRST
+	`
+
+	// queryLockProducts locks the products of a reservation in id order, deleted ones included.
+	queryLockProducts = `
+		SELECT id
+		FROM products
+		WHERE id = ANY(?::uuid[])
+		ORDER BY id
+		FOR UPDATE
+	`
+
+	queryProductStockColumns = `
+		SELECT
+			stock,
+			reserved_stock,
+			EXISTS (
+				SELECT 1 FROM product_variants WHERE product_id = products.id AND deleted_at IS NULL
+			) as has_variants
+		FROM products
+	`
+
+	// queryLockProductStock and queryLockVariantStock lock the row holding the stock of a reservation item,
+	// only an active product can be reserved.
+	queryLockProductStock = queryProductStockColumns + `
+		WHERE id = ? AND status = 'active' AND deleted_at IS NULL
+		FOR UPDATE
+	`
+
+	queryLockVariantStock = `
+		SELECT
+			stock,
+			reserved_stock,
+			false as has_variants
+		FROM product_variants
+		WHERE id = ? AND product_id = ? AND deleted_at IS NULL
+		FOR UPDATE
+	`
+
+	queryReserveProductStock = `
+		UPDATE products
+		SET
+			reserved_stock = reserved_stock + ?
+		WHERE id = ?
+	`
+
+	queryReserveVariantStock = `
+		UPDATE product_variants
+		SET
+			reserved_stock = reserved_stock + ?
+		WHERE id = ?
+	`
+
+	queryReleaseProductStock = `
+		UPDATE products
+		SET
+			reserved_stock = reserved_stock - ?
+		WHERE id = ?
+	`
+
+	queryReleaseVariantStock = `
+		UPDATE product_variants
+		SET
+			reserved_stock = reserved_stock - ?
+		WHERE id = ?
+	`
+)
@@ -0,0 +1,84 @@
+package repository
+
+const (
+	queryInsertReview = `
+		INSERT INTO product_reviews (
+			product_id,
+			user_id,
+			rating,
+			text
+		)
+		SELECT ?, ?, ?, ?
+		WHERE EXISTS (
+			SELECT 1 FROM products WHERE id = ? AND deleted_at IS NULL
+		)
+		RETURNING id
+	`
+
+	queryGetReviewsByProductId = `
+		SELECT
+			COUNT(id) OVER() as total_data,
+			id,
+			user_id,
+			rating,
+			text,
+			created_at,
+			updated_at
+		FROM product_reviews
+		WHERE product_id = ? AND deleted_at IS NULL
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	queryUpdateReview = `
+		UPDATE product_reviews
+		SET
+			rating = ?,
+			text = ?,
+			updated_at = NOW()
+		WHERE id = ? AND product_id = ? AND user_id = ? AND deleted_at IS NULL
+		RETURNING id
+	`
+
+	querySoftDeleteReview = `
+		UPDATE product_reviews
+		SET
+			deleted_at = NOW()
+		WHERE id = ? AND product_id = ? AND user_id = ? AND deleted_at IS NULL
+	`
+
+	// queryRefreshProductRating recomputes the average rating and review count of a product and returns its shop.
+	queryRefreshProductRating = `
+		UPDATE products p
+		SET
+			rating = COALESCE(r.rating, 0),
+			review_count = r.review_count
+		FROM (
+			SELECT
+				ROUND(AVG(rating), 2) as rating,
+				COUNT(id) as review_count
+			FROM product_reviews
+			WHERE product_id = ? AND deleted_at IS NULL
+		) r
+		WHERE p.id = ?
+		RETURNING p.shop_id
+	`
+
+	// queryRefreshShopRating averages every review of the products of a shop,
+	// so that products with more reviews weigh more.
+	queryRefreshShopRating = `
+		UPDATE shops s
+		SET
+			rating = COALESCE(r.rating, 0),
+			review_count = r.review_count
+		FROM (
+			SELECT
+				ROUND(AVG(pr.rating), 2) as rating,
+				COUNT(pr.id) as review_count
+			FROM product_reviews pr
+			JOIN products p ON p.id = pr.product_id
+			WHERE p.shop_id = ? AND p.deleted_at IS NULL AND pr.deleted_at IS NULL
+		) r
+		WHERE s.id = ?
+	`
+)
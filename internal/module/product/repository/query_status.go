@@ -0,0 +1,26 @@
+package repository
+
+const (
+	queryGetProductShop = `
+		SELECT shop_id
+		FROM products
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	queryLockProductStatus = `
+		SELECT status, version
+		FROM products
+		WHERE id = ? AND deleted_at IS NULL
+		FOR UPDATE
+	`
+
+	queryUpdateProductStatus = `
+		UPDATE products
+		SET
+			status = ?,
+			version = version + 1,
+			updated_at = NOW()
+		WHERE id = ?
+		RETURNING version
+	`
+)
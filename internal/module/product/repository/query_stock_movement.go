@@ -0,0 +1,61 @@
+package repository
+
+const (
+	// queryMoveProductStock and queryMoveVariantStock update nothing when the stock would go below
+	// the quantity held by the live reservations.
+	queryMoveProductStock = `
+		UPDATE products
+		SET
+			stock = stock + ?
+		WHERE id = ? AND stock + ? >= reserved_stock
+		RETURNING stock
+	`
+
+	queryMoveVariantStock = `
+		UPDATE product_variants
+		SET
+			stock = stock + ?
+		WHERE id = ? AND stock + ? >= reserved_stock
+		RETURNING stock
+	`
+
+	queryInsertStockMovement = `
+		INSERT INTO stock_movements (
+			product_id,
+			variant_id,
+			delta,
+			balance,
+			reason,
+			actor_user_id,
+			reference
+		) VALUES (?, ?, ?, ?, ?, CAST(NULLIF(?, '') AS UUID), NULLIF(?, ''))
+		RETURNING id, variant_id, delta, balance, reason, actor_user_id, reference, created_at
+	`
+
+	queryGetStockMovements = `
+		SELECT
+			COUNT(id) OVER() as total_data,
+			id,
+			variant_id,
+			delta,
+			balance,
+			reason,
+			actor_user_id,
+			reference,
+			created_at
+		FROM stock_movements
+		WHERE
+			product_id = ?
+			AND (? = '' OR variant_id = CAST(NULLIF(?, '') AS UUID))
+			AND (? = '' OR reason = ?)
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	// queryLockOwnedProductStock is queryLockProductStock for the shop owner, who also changes the stock of
+	// the products that are not active.
+	queryLockOwnedProductStock = queryProductStockColumns + `
+		WHERE id = ? AND deleted_at IS NULL
+		FOR UPDATE
+	`
+)
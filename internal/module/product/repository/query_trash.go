@@ -0,0 +1,64 @@
+package repository
+
+const (
+	queryGetTrashedProductShop = `
+		SELECT shop_id
+		FROM products
+		WHERE id = ? AND deleted_at IS NOT NULL
+	`
+
+	queryGetTrashedProducts = `
+		SELECT
+			COUNT(id) OVER() as total_data,
+			id,
+			name,
+			status,
+			price,
+			deleted_at
+		FROM products
+		WHERE shop_id = ? AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	queryRestoreProduct = `
+		UPDATE products
+		SET
+			deleted_at = NULL,
+			version = version + 1,
+			updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NOT NULL
+		RETURNING id, shop_id, name, status, version
+	`
+
+	queryLockTrashedProduct = `
+		SELECT id
+		FROM products
+		WHERE id = ? AND deleted_at IS NOT NULL
+		FOR UPDATE
+	`
+
+	// queryLockExpiredProducts picks the products deleted before the given time, the oldest first.
+	queryLockExpiredProducts = `
+		SELECT id
+		FROM products
+		WHERE deleted_at < ?
+		ORDER BY deleted_at
+		LIMIT ?
+		FOR UPDATE SKIP LOCKED
+	`
+
+	// queryPurgeProductImages returns the files of the images, they are removed from the storage afterward.
+	queryPurgeProductImages = `
+		DELETE FROM product_images
+		WHERE product_id = ANY(CAST(? AS UUID[]))
+		RETURNING id, position, is_primary, is_private, filename
+	`
+
+	// queryPurgeProducts also deletes the variants, reviews, questions, favorites, stock movements and prices of the products.
+	queryPurgeProducts = `
+		DELETE FROM products
+		WHERE id = ANY(CAST(? AS UUID[]))
+		RETURNING shop_id
+	`
+)
@@ -0,0 +1,72 @@
+package repository
+
+const (
+	// queryInsertVariant starts without stock, the initial stock is recorded as a stock movement.
+	queryInsertVariant = `
+		INSERT INTO product_variants (
+			product_id,
+			sku,
+			options,
+			price,
+			stock
+		)
+		SELECT ?, ?, ?, ?, 0
+		WHERE EXISTS (
+			SELECT 1 FROM products WHERE id = ? AND deleted_at IS NULL
+		)
+		RETURNING id
+	`
+
+	queryGetVariantsByProductId = `
+		SELECT
+			id,
+			sku,
+			options,
+			price,
+			sale_price,
+			stock,
+			GREATEST(stock - reserved_stock, 0) as available_stock
+		FROM product_variants
+		WHERE product_id = ? AND deleted_at IS NULL
+		ORDER BY created_at, id
+	`
+
+	queryUpdateVariant = `
+		UPDATE product_variants
+		SET
+			sku = ?,
+			options = ?,
+			price = ?,
+			updated_at = NOW()
+		WHERE id = ? AND product_id = ? AND deleted_at IS NULL
+		RETURNING id
+	`
+
+	querySoftDeleteVariant = `
+		UPDATE product_variants
+		SET
+			deleted_at = NOW()
+		WHERE id = ? AND product_id = ? AND deleted_at IS NULL
+	`
+
+	// queryRefreshVariantSummary recomputes the effective price range, total and available stock of a product
+	// from its variants, falling back to the product's own price and stock when it has none.
+	queryRefreshVariantSummary = `
+		UPDATE products p
+		SET
+			min_price = COALESCE(v.min_price, LEAST(p.price, p.sale_price)),
+			max_price = COALESCE(v.max_price, LEAST(p.price, p.sale_price)),
+			total_stock = COALESCE(v.total_stock, p.stock),
+			available_stock = COALESCE(v.available_stock, GREATEST(p.stock - p.reserved_stock, 0))
+		FROM (
+			SELECT
+				MIN(LEAST(price, sale_price)) as min_price,
+				MAX(LEAST(price, sale_price)) as max_price,
+				SUM(stock) as total_stock,
+				SUM(GREATEST(stock - reserved_stock, 0)) as available_stock
+			FROM product_variants
+			WHERE product_id = ? AND deleted_at IS NULL
+		) v
+		WHERE p.id = ?
+	`
+)
@@ -1,12 +1,21 @@
 package repository
 
 import (
+	"cmp"
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
+	outbox "github.com/hilmiikhsan/shopeefun-product-service/internal/module/outbox/entity"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/ports"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/etag"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/rs/zerolog/log"
 )
 
@@ -22,29 +31,152 @@ func NewProductRepository(db *sqlx.DB) *productRepository {
 	}
 }
 
+// withTx runs fn inside a transaction, rolling it back when fn returns an error.
+func (r *productRepository) withTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::withTx - Failed to begin transaction")
+		return err
+	}
+
+	if err = fn(tx); err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			log.Error().Err(errRollback).Msg("repository::withTx - Failed to rollback transaction")
+		}
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("repository::withTx - Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// lockProduct locks the row of a live product until the end of the transaction, the writes that
+// recompute a counter of the product must take it first so concurrent writes do not lose an update.
+func (r *productRepository) lockProduct(ctx context.Context, tx *sqlx.Tx, productId string) error {
+	var id string
+
+	return tx.QueryRowContext(ctx, tx.Rebind(queryLockProduct), productId).Scan(&id)
+}
+
 func (r *productRepository) CreateProduct(ctx context.Context, req *entity.CreateProductRequest) (*entity.CreateProductResponse, error) {
+	var resp *entity.CreateProductResponse
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var err error
+		resp, err = r.insertProduct(ctx, tx, req)
+		return err
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateProduct - Failed to create product")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// insertProduct inserts a product and records its initial stock.
+func (r *productRepository) insertProduct(ctx context.Context, tx *sqlx.Tx, req *entity.CreateProductRequest) (*entity.CreateProductResponse, error) {
 	var resp = new(entity.CreateProductResponse)
 
-	err := r.db.QueryRowContext(ctx, r.db.Rebind(queryInsertProduct),
+	category, brand, err := r.getReferenceNames(ctx, tx, req.CategoryId, req.BrandId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRowContext(ctx, tx.Rebind(queryInsertProduct),
 		req.ShopId,
 		req.Name,
 		req.Description,
-		req.Category,
+		req.CategoryId,
+		category,
+		req.BrandId,
+		brand,
+		req.Price,
 		req.Price,
-		req.Stock,
-	).Scan(&resp.Id, &resp.Name)
+		req.Price,
+		cmp.Or(req.Status, entity.ProductDraft),
+	).Scan(&resp.Id, &resp.Name, &resp.Status)
 	if err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("repository::CreateProduct - Failed to create product")
+		return nil, err
+	}
+
+	err = r.recordStockChange(ctx, tx, &entity.CreateStockMovementRequest{
+		ProductId: resp.Id,
+		UserId:    req.UserId,
+		Delta:     req.Stock,
+		Reason:    entity.StockRestock,
+		Reference: entity.InitialStockReference,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.recordPriceChange(ctx, tx, resp.Id, nil, entity.PriceCreated, "", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = r.refreshVariantSummary(ctx, tx, resp.Id); err != nil {
+		return nil, err
+	}
+
+	if err = r.addProductEvent(ctx, tx, outbox.ProductCreated, resp.Id); err != nil {
 		return nil, err
 	}
 
 	return resp, nil
 }
 
+// getReferenceNames returns the names of the category and brand of a product, they are copied on the product for search.
+func (r *productRepository) getReferenceNames(ctx context.Context, tx *sqlx.Tx, categoryId string, brandId *string) (string, *string, error) {
+	category, err := r.getCategoryName(ctx, tx, categoryId)
+	if err != nil {
+		return "", nil, err
+	}
+
+	brand, err := r.getBrandName(ctx, tx, brandId)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return category, brand, nil
+}
+
+func (r *productRepository) getCategoryName(ctx context.Context, tx *sqlx.Tx, categoryId string) (string, error) {
+	var category string
+
+	err := tx.QueryRowContext(ctx, tx.Rebind(queryGetCategoryName), categoryId).Scan(&category)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", entity.ErrCategoryNotFound
+	}
+
+	return category, err
+}
+
+// getBrandName returns nil for a product without brand.
+func (r *productRepository) getBrandName(ctx context.Context, tx *sqlx.Tx, brandId *string) (*string, error) {
+	if brandId == nil {
+		return nil, nil
+	}
+
+	var brand *string
+
+	err := tx.QueryRowContext(ctx, tx.Rebind(queryGetBrandName), *brandId).Scan(&brand)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrBrandNotFound
+	}
+
+	return brand, err
+}
+
 func (r *productRepository) GetProduct(ctx context.Context, req *entity.GetProductRequest) (*entity.GetProductResult, error) {
 	var resp = new(entity.GetProductResult)
 
-	err := r.db.QueryRowxContext(ctx, r.db.Rebind(queryGetProductById), req.Id).StructScan(resp)
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(queryGetProductById), req.UserId, req.Id, req.UserId).StructScan(resp)
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("repository::GetProduct - Failed to get product")
 		return nil, err
@@ -60,112 +192,302 @@ func (r *productRepository) GetProducts(ctx context.Context, req *entity.Product
 	}
 
 	var (
-		resp  = new(entity.ProductsResponse)
-		data  = make([]dao, 0, req.Paginate)
-		query = queryGetProducts
+		resp          = new(entity.ProductsResponse)
+		order         = productsOrders[req.SortKey()]
+		keyset        = req.After != nil
+		backward      = keyset && req.After.Backward
+		limit         = req.Paginate
+		totalColumn   = queryTotalColumn
+		searchColumns = queryNoSearchColumns
 	)
 
-	resp.Items = make([]entity.ProductItem, 0, req.Paginate)
-	var minPrice, maxPrice float64
-	var rating int64
-	var err error
+	if req.Q != "" {
+		searchColumns = querySearchColumns
+	}
+
+	// keyset pages fetch one extra row to know whether another page exists
+	if keyset {
+		totalColumn = queryNoTotalColumn
+		limit++
+	}
+
+	conditions, params, err := productsFilter(req)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetProducts - Failed to build filter")
+		return nil, err
+	}
+
+	query := fmt.Sprintf(queryGetProducts, totalColumn, searchColumns) + conditions
+
+	if keyset {
+		query += order.keyset(backward) + order.orderBy(backward) + " LIMIT :limit"
+		params["cursor_value"] = req.After.Value
+		params["cursor_id"] = req.After.Id
+	} else {
+		query += order.orderBy(false) + " LIMIT :limit OFFSET :offset"
+		params["offset"] = req.Paginate * (req.Page - 1)
+	}
+	params["limit"] = limit
+
+	query, args, err := sqlx.Named(query, params)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::GetProducts - Failed to bind named query")
+		return nil, err
+	}
+
+	data := make([]dao, 0, limit)
+
+	err = r.db.SelectContext(ctx, &data, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetProducts - Failed to get products")
+		return nil, err
+	}
+
+	hasMore := len(data) > req.Paginate
+	if hasMore {
+		data = data[:req.Paginate]
+	}
+
+	if backward {
+		slices.Reverse(data)
+	}
+
+	resp.Items = make([]entity.ProductItem, 0, len(data))
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.ProductItem)
+	}
+
+	var hasNext, hasPrev bool
+	if keyset {
+		// a keyset page always has rows on the side it came from
+		hasNext = !backward || hasMore
+		hasPrev = backward || hasMore
+
+		resp.Meta.Paginate = req.Paginate
+	} else {
+		if len(data) > 0 {
+			resp.Meta.TotalData = data[0].TotalData
+		}
+
+		resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+		hasNext = req.Page < resp.Meta.TotalPage
+		hasPrev = req.Page > 1
+	}
+
+	if len(resp.Items) > 0 {
+		if hasNext {
+			resp.Meta.NextCursor = order.cursor(req.SortKey(), &resp.Items[len(resp.Items)-1], false)
+		}
+
+		if hasPrev {
+			resp.Meta.PrevCursor = order.cursor(req.SortKey(), &resp.Items[0], true)
+		}
+	}
+
+	if facets := req.FacetList(); len(facets) > 0 {
+		resp.Facets, err = r.getFacets(ctx, facets, conditions, params)
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::GetProducts - Failed to get facets")
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// productsFilter returns the conditions appended after "WHERE deleted_at IS NULL" and their named params,
+// the listing and the facets share it so that the counts match the listed products.
+func productsFilter(req *entity.ProductRequest) (string, map[string]any, error) {
+	var (
+		conditions string
+		minPrice   float64
+		maxPrice   float64
+		rating     float64
+		err        error
+	)
 
 	if req.MinPrice != "" {
 		minPrice, err = strconv.ParseFloat(req.MinPrice, 64)
 		if err != nil {
-			log.Error().Err(err).Any("payload", req).Msg("repository::GetProducts - Failed to parse min price")
-			return nil, err
+			return "", nil, err
 		}
 	}
 
 	if req.MaxPrice != "" {
 		maxPrice, err = strconv.ParseFloat(req.MaxPrice, 64)
 		if err != nil {
-			log.Error().Err(err).Any("payload", req).Msg("repository::GetProducts - Failed to parse max price")
-			return nil, err
+			return "", nil, err
 		}
 	}
 
 	if req.Rating != "" {
-		rating, err = strconv.ParseInt(req.Rating, 10, 64)
+		rating, err = strconv.ParseFloat(req.Rating, 64)
 		if err != nil {
-			log.Error().Err(err).Any("payload", req).Msg("repository::GetProducts - Failed to parse rating")
-			return nil, err
+			return "", nil, err
 		}
 	}
 
 	if req.Category != "" {
-		query += " AND category = :category"
+		conditions += " AND category_id IN (" + queryCategoryTreeBySlug + ")"
+	}
+
+	if req.CategoryId != "" {
+		conditions += " AND category_id IN (" + queryCategoryTreeById + ")"
 	}
 
+	// a product matches a price range when any of its variants falls inside it
 	if minPrice > 0 {
-		query += " AND price >= :min_price"
+		conditions += " AND max_price >= :min_price"
 	}
 
 	if maxPrice > 0 {
-		query += " AND price <= :max_price"
+		conditions += " AND min_price <= :max_price"
 	}
 
-	if req.Brand != "" {
-		query += " AND brand = :brand"
+	if req.InStock {
+		conditions += " AND available_stock > 0"
 	}
 
-	if rating > 0 {
-		query += " AND rating >= :rating"
+	if req.ShopId != "" {
+		conditions += " AND shop_id = :shop_id"
 	}
 
-	if req.Name != "" {
-		query += " AND name ILIKE '%' || :name || '%'"
+	if req.Mine {
+		conditions += " AND shop_id IN (" + queryViewerShopIds + ")"
 	}
 
-	query += " LIMIT :limit OFFSET :offset"
-
-	query, args, err := sqlx.Named(query, map[string]interface{}{
-		"limit":     req.Paginate,
-		"offset":    req.Paginate * (req.Page - 1),
-		"category":  req.Category,
-		"min_price": req.MinPrice,
-		"max_price": req.MaxPrice,
-		"brand":     req.Brand,
-		"rating":    req.Rating,
-		"name":      req.Name,
-	})
-	if err != nil {
-		log.Error().Err(err).Msg("repository::GetProducts - Failed to bind named query")
-		return nil, err
+	brands := req.BrandList()
+	if len(brands) > 0 {
+		conditions += " AND brand_id IN (" + queryBrandIdsBySlugs + ")"
 	}
 
-	query = r.db.Rebind(query)
-
-	err = r.db.SelectContext(ctx, &data, query, args...)
-	if err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("repository::GetProducts - Failed to get products")
-		return nil, err
+	if rating > 0 {
+		conditions += " AND rating >= :rating"
 	}
 
-	if len(data) > 0 {
-		resp.Meta.TotalData = data[0].TotalData
+	if req.Name != "" {
+		conditions += " AND name ILIKE '%' || :name || '%'"
 	}
 
-	for _, d := range data {
-		resp.Items = append(resp.Items, d.ProductItem)
+	if req.Q != "" {
+		conditions += " AND search_vector @@ websearch_to_tsquery('simple', :q)"
 	}
 
-	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+	if req.Status != "" {
+		conditions += " AND status = :status"
+	}
 
-	return resp, nil
+	conditions += " AND (status = 'active' OR shop_id IN (" + queryViewerShopIds + "))"
+
+	return conditions, map[string]any{
+		"category":    req.Category,
+		"category_id": req.CategoryId,
+		"min_price":   req.MinPrice,
+		"max_price":   req.MaxPrice,
+		"brands":      pq.Array(brands),
+		"rating":      req.Rating,
+		"name":        req.Name,
+		"q":           req.Q,
+		"status":      req.Status,
+		"viewer_id":   req.ViewerId,
+		"shop_id":     req.ShopId,
+	}, nil
 }
 
 func (r *productRepository) UpdateProduct(ctx context.Context, req *entity.UpdateProductRequest) (*entity.UpdateProductResponse, error) {
 	var resp = new(entity.UpdateProductResponse)
 
-	err := r.db.QueryRowxContext(ctx, r.db.Rebind(queryUpdateProduct),
-		req.Name,
-		req.Description,
-		req.Category,
-		req.Price,
-		req.Stock,
-	).Scan(&resp.Id)
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var current stockRow
+		err := tx.QueryRowxContext(ctx, tx.Rebind(queryLockOwnedProductStock), req.Id).StructScan(&current)
+		if err != nil {
+			return err
+		}
+
+		var version int
+		if err := tx.QueryRowContext(ctx, tx.Rebind(queryGetProductVersion), req.Id).Scan(&version); err != nil {
+			return err
+		}
+
+		if !etag.Match(req.IfMatch, version) {
+			return entity.ErrStaleVersion
+		}
+
+		var (
+			sets   = make([]string, 0, len(entity.UpdateProductFields))
+			params = map[string]any{"id": req.Id, "shop_id": req.ShopId}
+		)
+		set := func(column string, value any) {
+			sets = append(sets, column+" = :"+column+",")
+			params[column] = value
+		}
+
+		if req.Fields.Has("name") {
+			set("name", req.Name)
+		}
+
+		if req.Fields.Has("description") {
+			set("description", req.Description)
+		}
+
+		if req.Fields.Has("category_id") {
+			category, err := r.getCategoryName(ctx, tx, *req.CategoryId)
+			if err != nil {
+				return err
+			}
+			set("category_id", req.CategoryId)
+			set("category", category)
+		}
+
+		if req.Fields.Has("brand_id") {
+			brand, err := r.getBrandName(ctx, tx, req.BrandId)
+			if err != nil {
+				return err
+			}
+			set("brand_id", req.BrandId)
+			set("brand", brand)
+		}
+
+		if req.Fields.Has("price") {
+			set("price", req.Price)
+		}
+
+		query, args, err := sqlx.Named(fmt.Sprintf(queryUpdateProduct, strings.Join(sets, "\n\t\t\t")), params)
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRowxContext(ctx, tx.Rebind(query), args...).Scan(&resp.Id, &resp.Name, &resp.Version)
+		if err != nil {
+			return err
+		}
+
+		if req.Fields.Has("stock") {
+			err = r.recordStockChange(ctx, tx, &entity.CreateStockMovementRequest{
+				ProductId: req.Id,
+				UserId:    req.UserId,
+				Delta:     *req.Stock - current.Stock,
+				Reason:    entity.StockAdjustment,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if req.Fields.Has("price") {
+			err = r.recordPriceChange(ctx, tx, req.Id, nil, entity.PriceUpdated, "", req.UserId)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := r.refreshVariantSummary(ctx, tx, req.Id); err != nil {
+			return err
+		}
+
+		return r.addProductEvent(ctx, tx, outbox.ProductUpdated, req.Id)
+	})
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateProduct - Failed to update product")
 		return nil, err
@@ -175,7 +497,27 @@ func (r *productRepository) UpdateProduct(ctx context.Context, req *entity.Updat
 }
 
 func (r *productRepository) DeleteProduct(ctx context.Context, req *entity.DeleteProductRequest) error {
-	_, err := r.db.ExecContext(ctx, r.db.Rebind(queryDeleteProduct), req.Id)
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, tx.Rebind(queryDeleteProduct), req.Id, req.ShopId)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return sql.ErrNoRows
+		}
+
+		if err := r.refreshShopRating(ctx, tx, req.ShopId); err != nil {
+			return err
+		}
+
+		return r.addProductEvent(ctx, tx, outbox.ProductDeleted, req.Id)
+	})
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteProduct - Failed to delete product")
 		return err
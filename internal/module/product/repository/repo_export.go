@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+// ExportProducts calls fn with every product of a shop matching the listing filters. The rows are read
+// one by one from the database as fn consumes them, the result is never loaded in memory.
+func (r *productRepository) ExportProducts(ctx context.Context, req *entity.ExportProductsRequest, fn func(item *entity.ProductItem) error) error {
+	var (
+		order         = productsOrders[req.SortKey()]
+		searchColumns = queryNoSearchColumns
+	)
+
+	if req.Q != "" {
+		searchColumns = querySearchColumns
+	}
+
+	filter := req.ProductRequest
+	filter.ShopId = req.ShopId
+
+	conditions, params, err := productsFilter(&filter)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ExportProducts - Failed to build filter")
+		return err
+	}
+
+	query := fmt.Sprintf(queryGetProducts, queryNoTotalColumn, searchColumns) +
+		conditions + order.orderBy(false)
+
+	query, args, err := sqlx.Named(query, params)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::ExportProducts - Failed to bind named query")
+		return err
+	}
+
+	rows, err := r.db.QueryxContext(ctx, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ExportProducts - Failed to get products")
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item struct {
+			TotalData int `db:"total_data"`
+			entity.ProductItem
+		}
+
+		if err := rows.StructScan(&item); err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::ExportProducts - Failed to scan product")
+			return err
+		}
+
+		if err := fn(&item.ProductItem); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ExportProducts - Failed to read products")
+		return err
+	}
+
+	return nil
+}
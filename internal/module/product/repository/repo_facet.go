@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+func (r *productRepository) getFacets(ctx context.Context, facets []string, conditions string, params map[string]any) (*entity.ProductFacets, error) {
+	var (
+		resp = new(entity.ProductFacets)
+		err  error
+	)
+
+	for _, facet := range facets {
+		switch facet {
+		case entity.FacetCategory:
+			resp.Category, err = r.getFacetBuckets(ctx, fmt.Sprintf(queryFacetCategory, conditions), params)
+		case entity.FacetBrand:
+			resp.Brand, err = r.getFacetBuckets(ctx, fmt.Sprintf(queryFacetBrand, conditions), params)
+		case entity.FacetRating:
+			resp.Rating, err = r.getFacetBuckets(ctx, fmt.Sprintf(queryFacetRating, conditions), params)
+		case entity.FacetPrice:
+			resp.Price, err = r.getPriceFacet(ctx, conditions, params)
+		}
+
+		if err != nil {
+			log.Error().Err(err).Str("facet", facet).Msg("repository::getFacets - Failed to get facet")
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) getFacetBuckets(ctx context.Context, query string, params map[string]any) ([]entity.FacetBucket, error) {
+	var resp = make([]entity.FacetBucket, 0)
+
+	query, args, err := sqlx.Named(query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.SelectContext(ctx, &resp, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) getPriceFacet(ctx context.Context, conditions string, params map[string]any) ([]entity.PriceFacetBucket, error) {
+	type dao struct {
+		Bucket int `db:"bucket"`
+		Count  int `db:"count"`
+	}
+
+	var (
+		boundaries = entity.PriceFacetBoundaries
+		literals   = make([]string, 0, len(boundaries))
+		data       = make([]dao, 0, len(boundaries)+1)
+	)
+
+	for _, b := range boundaries {
+		literals = append(literals, strconv.FormatFloat(b, 'f', 2, 64))
+	}
+
+	query, args, err := sqlx.Named(fmt.Sprintf(queryFacetPrice, strings.Join(literals, ", "), conditions), params)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.SelectContext(ctx, &data, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// width_bucket returns 0 below the first boundary and len(boundaries) from the last one,
+	// every bucket is returned so the storefront can render empty ranges too
+	resp := make([]entity.PriceFacetBucket, len(boundaries)+1)
+	for i := range resp {
+		if i > 0 {
+			resp[i].Min = boundaries[i-1]
+		}
+
+		if i < len(boundaries) {
+			max := boundaries[i]
+			resp[i].Max = &max
+		}
+	}
+
+	for _, d := range data {
+		resp[d.Bucket].Count = d.Count
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+func (r *productRepository) AddFavorite(ctx context.Context, req *entity.FavoriteRequest) (*entity.FavoriteResponse, error) {
+	var resp = &entity.FavoriteResponse{ProductId: req.ProductId, IsFavorited: true}
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		if err := r.lockProduct(ctx, tx, req.ProductId); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, tx.Rebind(queryInsertFavorite), req.UserId, req.ProductId)
+		if err != nil {
+			return err
+		}
+
+		return tx.QueryRowContext(ctx, tx.Rebind(queryRefreshFavoriteCount), req.ProductId, req.ProductId).Scan(&resp.FavoriteCount)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::AddFavorite - Failed to add favorite")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// RemoveFavorite removes a product from a wishlist, sql.ErrNoRows is returned when the product does not exist.
+func (r *productRepository) RemoveFavorite(ctx context.Context, req *entity.FavoriteRequest) (*entity.FavoriteResponse, error) {
+	var resp = &entity.FavoriteResponse{ProductId: req.ProductId}
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		if err := r.lockProduct(ctx, tx, req.ProductId); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, tx.Rebind(queryDeleteFavorite), req.UserId, req.ProductId)
+		if err != nil {
+			return err
+		}
+
+		return tx.QueryRowContext(ctx, tx.Rebind(queryRefreshFavoriteCount), req.ProductId, req.ProductId).Scan(&resp.FavoriteCount)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::RemoveFavorite - Failed to remove favorite")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) GetFavorites(ctx context.Context, req *entity.FavoritesRequest) (*entity.FavoritesResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.FavoriteItem
+	}
+
+	var (
+		resp = new(entity.FavoritesResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.FavoriteItem, 0, req.Paginate)
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(queryGetFavorites),
+		req.UserId,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetFavorites - Failed to get favorites")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.FavoriteItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+func (r *productRepository) CreateImages(ctx context.Context, productId string, reqs []entity.CreateImageRequest) ([]entity.ImageItem, error) {
+	var resp = make([]entity.ImageItem, 0, len(reqs))
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		// lock the product so concurrent uploads get distinct positions
+		var id string
+		if err := tx.QueryRowContext(ctx, tx.Rebind(queryLockProduct), productId).Scan(&id); err != nil {
+			return err
+		}
+
+		for _, req := range reqs {
+			var item entity.ImageItem
+			err := tx.QueryRowxContext(ctx, tx.Rebind(queryInsertImage),
+				req.ProductId,
+				req.Filename,
+				req.ContentType,
+				req.Size,
+				req.IsPrivate,
+				req.ProductId,
+			).StructScan(&item)
+			if err != nil {
+				return err
+			}
+
+			resp = append(resp, item)
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Str("product_id", productId).Any("payload", reqs).Msg("repository::CreateImages - Failed to create images")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) GetImages(ctx context.Context, req *entity.ImagesRequest) ([]entity.ImageItem, error) {
+	var resp = make([]entity.ImageItem, 0)
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(queryGetImagesByProductId), req.ProductId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetImages - Failed to get images")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) ReorderImages(ctx context.Context, req *entity.ReorderImagesRequest) error {
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(queryReorderImages), pq.Array(req.ImageIds), req.ProductId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ReorderImages - Failed to reorder images")
+		return err
+	}
+
+	return nil
+}
+
+func (r *productRepository) SetPrimaryImage(ctx context.Context, req *entity.SetPrimaryImageRequest) error {
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx, tx.Rebind(queryUnsetPrimaryImage), req.ProductId); err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, tx.Rebind(querySetPrimaryImage), req.Id, req.ProductId)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return sql.ErrNoRows
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::SetPrimaryImage - Failed to set primary image")
+		return err
+	}
+
+	return nil
+}
+
+func (r *productRepository) DeleteImage(ctx context.Context, req *entity.DeleteImageRequest) (*entity.ImageItem, error) {
+	var resp = new(entity.ImageItem)
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		err := tx.QueryRowxContext(ctx, tx.Rebind(queryDeleteImage), req.Id, req.ProductId).StructScan(resp)
+		if err != nil {
+			return err
+		}
+
+		if !resp.IsPrimary {
+			return nil
+		}
+
+		_, err = tx.ExecContext(ctx, tx.Rebind(queryPromoteFirstImage), req.ProductId)
+		return err
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteImage - Failed to delete image")
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+// ImportProducts inserts a batch of products in one transaction. Every row runs inside its own savepoint,
+// so a rejected row is rolled back alone and reported in its result instead of failing the batch.
+func (r *productRepository) ImportProducts(ctx context.Context, reqs []entity.CreateProductRequest) ([]entity.ImportResult, error) {
+	var results = make([]entity.ImportResult, len(reqs))
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		for i := range reqs {
+			if _, err := tx.ExecContext(ctx, querySavepointImportRow); err != nil {
+				return err
+			}
+
+			product, err := r.insertProduct(ctx, tx, &reqs[i])
+			if err != nil {
+				results[i].Err = err
+
+				if _, err := tx.ExecContext(ctx, queryRollbackImportRow); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if _, err := tx.ExecContext(ctx, queryReleaseImportRow); err != nil {
+				return err
+			}
+
+			results[i].Product = product
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Int("total", len(reqs)).Msg("repository::ImportProducts - Failed to import products")
+		return nil, err
+	}
+
+	return results, nil
+}
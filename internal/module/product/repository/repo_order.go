@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/types"
+)
+
+// productsOrder is a sort order of the product listing, the id is always the tiebreaker
+// in the same direction so that (column, id) is unique and can be used as a keyset.
+type productsOrder struct {
+	column string
+	desc   bool
+	value  func(item *entity.ProductItem) string // cursor value of a row, parsed back by postgres
+}
+
+var productsOrders = map[string]productsOrder{
+	entity.SortPriceAsc: {
+		column: "min_price",
+		desc:   false,
+		value:  minPriceValue,
+	},
+	entity.SortPriceDesc: {
+		column: "min_price",
+		desc:   true,
+		value:  minPriceValue,
+	},
+	entity.SortNewest: {
+		column: "created_at",
+		desc:   true,
+		value: func(item *entity.ProductItem) string {
+			return item.CreatedAt.Format(time.RFC3339Nano)
+		},
+	},
+	entity.SortRating: {
+		column: "rating",
+		desc:   true,
+		value: func(item *entity.ProductItem) string {
+			return strconv.FormatFloat(item.Rating, 'f', 2, 64)
+		},
+	},
+	entity.SortName: {
+		column: "name",
+		desc:   false,
+		value: func(item *entity.ProductItem) string {
+			return item.Name
+		},
+	},
+	entity.SortRelevance: {
+		column: "ts_rank(search_vector, websearch_to_tsquery('simple', :q))",
+		desc:   true,
+		value: func(item *entity.ProductItem) string {
+			return strconv.FormatFloat(item.Rank, 'g', -1, 64)
+		},
+	},
+}
+
+// orderBy reverses the direction when walking backward from a prev_cursor.
+func (o productsOrder) orderBy(backward bool) string {
+	dir := "ASC"
+	if o.desc != backward {
+		dir = "DESC"
+	}
+
+	return fmt.Sprintf(" ORDER BY %s %s, id %s", o.column, dir, dir)
+}
+
+// keyset returns the condition of the rows after (or before when backward) the cursor named params.
+func (o productsOrder) keyset(backward bool) string {
+	op := ">"
+	if o.desc != backward {
+		op = "<"
+	}
+
+	return fmt.Sprintf(" AND (%s, id) %s (:cursor_value, :cursor_id)", o.column, op)
+}
+
+func (o productsOrder) cursor(sort string, item *entity.ProductItem, backward bool) string {
+	return types.Cursor{
+		Sort:     sort,
+		Value:    o.value(item),
+		Id:       item.Id,
+		Backward: backward,
+	}.Encode()
+}
+
+func minPriceValue(item *entity.ProductItem) string {
+	return strconv.FormatFloat(item.MinPrice, 'f', 2, 64)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	outbox "github.com/hilmiikhsan/shopeefun-product-service/internal/module/outbox/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// addProductEvent writes an event of the products to the outbox in the transaction of the write. It is
+// called once the products are locked and refreshed, so that the events of a product follow the writes.
+func (r *productRepository) addProductEvent(ctx context.Context, tx *sqlx.Tx, eventType string, productIds ...string) error {
+	_, err := tx.ExecContext(ctx, tx.Rebind(queryInsertProductEvents), outbox.AggregateProduct, eventType, pq.Array(productIds))
+	return err
+}
+
+// addStockEvent writes the stock.changed event of a stock movement to the outbox.
+func (r *productRepository) addStockEvent(ctx context.Context, tx *sqlx.Tx, movementId string) error {
+	_, err := tx.ExecContext(ctx, tx.Rebind(queryInsertStockEvent), outbox.AggregateProduct, outbox.StockChanged, movementId)
+	return err
+}
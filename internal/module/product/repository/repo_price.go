@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	outbox "github.com/hilmiikhsan/shopeefun-product-service/internal/module/outbox/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+// priceSchedule is a price schedule locked for the rest of the transaction.
+type priceSchedule struct {
+	Id        string  `db:"id"`
+	ProductId string  `db:"product_id"`
+	VariantId *string `db:"variant_id"`
+	SalePrice float64 `db:"sale_price"`
+	Status    string  `db:"status"`
+	Expired   bool    `db:"expired"`
+}
+
+func (r *productRepository) CreatePriceSchedule(ctx context.Context, req *entity.CreatePriceScheduleRequest) (*entity.PriceScheduleItem, error) {
+	var resp = new(entity.PriceScheduleItem)
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		price, err := r.lockPrice(ctx, tx, req.ProductId, req.VariantId)
+		if err != nil {
+			return err
+		}
+
+		if req.SalePrice >= price {
+			return entity.ErrSalePriceNotLower
+		}
+
+		var overlaps bool
+		err = tx.QueryRowContext(ctx, tx.Rebind(queryPriceScheduleOverlaps),
+			req.ProductId,
+			req.VariantId,
+			req.EndsAt,
+			req.StartsAt,
+		).Scan(&overlaps)
+		if err != nil {
+			return err
+		}
+
+		if overlaps {
+			return entity.ErrScheduleOverlap
+		}
+
+		return tx.QueryRowxContext(ctx, tx.Rebind(queryInsertPriceSchedule),
+			req.ProductId,
+			req.VariantId,
+			req.SalePrice,
+			req.StartsAt,
+			req.EndsAt,
+			req.UserId,
+		).StructScan(resp)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreatePriceSchedule - Failed to create price schedule")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) GetPriceSchedules(ctx context.Context, req *entity.PriceSchedulesRequest) (*entity.PriceSchedulesResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.PriceScheduleItem
+	}
+
+	var (
+		resp = new(entity.PriceSchedulesResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.PriceScheduleItem, 0, req.Paginate)
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(queryGetPriceSchedules),
+		req.ProductId,
+		req.Status,
+		req.Status,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetPriceSchedules - Failed to get price schedules")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.PriceScheduleItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+// CancelPriceSchedule cancels a pending schedule, an active one is ended right away and its sale price reverted.
+func (r *productRepository) CancelPriceSchedule(ctx context.Context, req *entity.CancelPriceScheduleRequest) error {
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var schedule priceSchedule
+
+		err := tx.QueryRowxContext(ctx, tx.Rebind(queryLockPriceSchedule), req.Id, req.ProductId).StructScan(&schedule)
+		if err != nil {
+			return err
+		}
+
+		switch schedule.Status {
+		case entity.ScheduleEnded, entity.ScheduleCancelled:
+			return entity.ErrScheduleFinished
+		case entity.ScheduleActive:
+			err := r.setSalePrice(ctx, tx, &schedule, nil, entity.PriceSaleEnded, req.UserId)
+			if err != nil && !errors.Is(err, entity.ErrPriceTargetMissing) {
+				return err
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, tx.Rebind(queryUpdatePriceScheduleStatus), entity.ScheduleCancelled, schedule.Id)
+		return err
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CancelPriceSchedule - Failed to cancel price schedule")
+		return err
+	}
+
+	return nil
+}
+
+// ApplyPriceSchedules ends the active schedules past their end and starts the schedules past their start,
+// at most limit of them, and returns how many were applied.
+func (r *productRepository) ApplyPriceSchedules(ctx context.Context, limit int) (int, error) {
+	var applied int
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var schedules []priceSchedule
+
+		// schedules being applied by another worker are skipped
+		err := tx.SelectContext(ctx, &schedules, tx.Rebind(queryLockDuePriceSchedules), limit)
+		if err != nil {
+			return err
+		}
+
+		for i := range schedules {
+			schedule := &schedules[i]
+
+			status, err := r.applyPriceSchedule(ctx, tx, schedule)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.ExecContext(ctx, tx.Rebind(queryUpdatePriceScheduleStatus), status, schedule.Id)
+			if err != nil {
+				return err
+			}
+		}
+
+		applied = len(schedules)
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("repository::ApplyPriceSchedules - Failed to apply price schedules")
+		return 0, err
+	}
+
+	return applied, nil
+}
+
+// applyPriceSchedule starts or ends a due schedule and returns its new status.
+func (r *productRepository) applyPriceSchedule(ctx context.Context, tx *sqlx.Tx, schedule *priceSchedule) (string, error) {
+	if schedule.Status == entity.ScheduleActive {
+		err := r.setSalePrice(ctx, tx, schedule, nil, entity.PriceSaleEnded, "")
+		if err != nil && !errors.Is(err, entity.ErrPriceTargetMissing) {
+			return "", err
+		}
+
+		return entity.ScheduleEnded, nil
+	}
+
+	// the whole period went by while the worker was not running
+	if schedule.Expired {
+		return entity.ScheduleEnded, nil
+	}
+
+	err := r.setSalePrice(ctx, tx, schedule, &schedule.SalePrice, entity.PriceSaleStarted, "")
+	if errors.Is(err, entity.ErrPriceTargetMissing) {
+		return entity.ScheduleCancelled, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return entity.ScheduleActive, nil
+}
+
+// setSalePrice sets or clears the sale price of the product or variant of a schedule and records it in the history.
+func (r *productRepository) setSalePrice(ctx context.Context, tx *sqlx.Tx, schedule *priceSchedule, salePrice *float64, reason, actor string) error {
+	_, err := r.lockPrice(ctx, tx, schedule.ProductId, schedule.VariantId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ErrPriceTargetMissing
+	}
+	if err != nil {
+		return err
+	}
+
+	var (
+		query = querySetProductSalePrice
+		id    = schedule.ProductId
+	)
+	if schedule.VariantId != nil {
+		query, id = querySetVariantSalePrice, *schedule.VariantId
+	}
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), salePrice, id); err != nil {
+		return err
+	}
+
+	err = r.recordPriceChange(ctx, tx, schedule.ProductId, schedule.VariantId, reason, schedule.Id, actor)
+	if err != nil {
+		return err
+	}
+
+	if err := r.refreshVariantSummary(ctx, tx, schedule.ProductId); err != nil {
+		return err
+	}
+
+	return r.addProductEvent(ctx, tx, outbox.ProductUpdated, schedule.ProductId)
+}
+
+// lockPrice locks the product, then the variant when given, and returns the price of the locked item.
+func (r *productRepository) lockPrice(ctx context.Context, tx *sqlx.Tx, productId string, variantId *string) (float64, error) {
+	var price float64
+
+	err := tx.QueryRowContext(ctx, tx.Rebind(queryLockProductPrice), productId).Scan(&price)
+	if err != nil || variantId == nil {
+		return price, err
+	}
+
+	err = tx.QueryRowContext(ctx, tx.Rebind(queryLockVariantPrice), *variantId, productId).Scan(&price)
+	return price, err
+}
+
+// recordPriceChange copies the current price of a product or variant into its history, nothing is
+// recorded when it did not change since the latest entry.
+func (r *productRepository) recordPriceChange(ctx context.Context, tx *sqlx.Tx, productId string, variantId *string, reason, scheduleId, actor string) error {
+	var (
+		query = queryInsertProductPriceHistory
+		id    = productId
+	)
+	if variantId != nil {
+		query, id = queryInsertVariantPriceHistory, *variantId
+	}
+
+	_, err := tx.ExecContext(ctx, tx.Rebind(query), reason, scheduleId, actor, id)
+	return err
+}
+
+func (r *productRepository) GetPriceHistory(ctx context.Context, req *entity.PriceHistoryRequest) (*entity.PriceHistoryResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.PriceHistoryItem
+	}
+
+	var (
+		resp = new(entity.PriceHistoryResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.PriceHistoryItem, 0, req.Paginate)
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(queryGetPriceHistory),
+		req.ProductId,
+		req.VariantId,
+		req.VariantId,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetPriceHistory - Failed to get price history")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.PriceHistoryItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
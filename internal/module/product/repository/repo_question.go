@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+func (r *productRepository) CreateQuestion(ctx context.Context, req *entity.CreateQuestionRequest) (*entity.CreateQuestionResponse, error) {
+	var resp = new(entity.CreateQuestionResponse)
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		if err := r.lockProduct(ctx, tx, req.ProductId); err != nil {
+			return err
+		}
+
+		err := tx.QueryRowContext(ctx, tx.Rebind(queryInsertQuestion),
+			req.ProductId,
+			req.UserId,
+			req.Question,
+			req.ProductId,
+		).Scan(&resp.Id)
+		if err != nil {
+			return err
+		}
+
+		return r.refreshQuestionCount(ctx, tx, req.ProductId)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateQuestion - Failed to create question")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) GetQuestions(ctx context.Context, req *entity.QuestionsRequest) (*entity.QuestionsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.QuestionItem
+	}
+
+	var (
+		resp = new(entity.QuestionsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.QuestionItem, 0, req.Paginate)
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(queryGetAnsweredQuestions),
+		req.ProductId,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetQuestions - Failed to get questions")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.QuestionItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *productRepository) AnswerQuestion(ctx context.Context, req *entity.AnswerQuestionRequest) (*entity.AnswerQuestionResponse, error) {
+	var resp = new(entity.AnswerQuestionResponse)
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		if err := r.lockProduct(ctx, tx, req.ProductId); err != nil {
+			return err
+		}
+
+		err := tx.QueryRowContext(ctx, tx.Rebind(queryAnswerQuestion),
+			req.Answer,
+			req.UserId,
+			req.Id,
+			req.ProductId,
+		).Scan(&resp.Id, &resp.AnsweredAt)
+		if err != nil {
+			return err
+		}
+
+		return r.refreshQuestionCount(ctx, tx, req.ProductId)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::AnswerQuestion - Failed to answer question")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) DeleteQuestion(ctx context.Context, req *entity.DeleteQuestionRequest) error {
+	var askedBy string
+	if !req.Owner {
+		askedBy = req.UserId
+	}
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		if err := r.lockProduct(ctx, tx, req.ProductId); err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, tx.Rebind(querySoftDeleteQuestion), req.Id, req.ProductId, askedBy, askedBy)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return sql.ErrNoRows
+		}
+
+		return r.refreshQuestionCount(ctx, tx, req.ProductId)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteQuestion - Failed to delete question")
+		return err
+	}
+
+	return nil
+}
+
+func (r *productRepository) GetUnansweredQuestions(ctx context.Context, req *entity.UnansweredQuestionsRequest) (*entity.UnansweredQuestionsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.UnansweredQuestionItem
+	}
+
+	var (
+		resp = new(entity.UnansweredQuestionsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.UnansweredQuestionItem, 0, req.Paginate)
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(queryGetUnansweredQuestions),
+		req.ShopId,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetUnansweredQuestions - Failed to get unanswered questions")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.UnansweredQuestionItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+// refreshQuestionCount recomputes the question and answered counts of a product,
+// the caller must hold the lock of the product.
+func (r *productRepository) refreshQuestionCount(ctx context.Context, tx *sqlx.Tx, productId string) error {
+	_, err := tx.ExecContext(ctx, tx.Rebind(queryRefreshQuestionCount), productId, productId)
+	return err
+}
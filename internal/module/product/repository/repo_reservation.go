@@ -0,0 +1,308 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// stockRow is the stock of a product or variant, locked for the rest of the transaction.
+type stockRow struct {
+	Stock         int  `db:"stock"`
+	ReservedStock int  `db:"reserved_stock"`
+	HasVariants   bool `db:"has_variants"`
+}
+
+func (r *productRepository) ReserveStock(ctx context.Context, req *entity.ReserveStockRequest) (*entity.ReservationResponse, error) {
+	// rows are always locked in (product_id, variant_id) order so that concurrent reservations can not deadlock
+	order := make([]int, len(req.Items))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int {
+		return compareItems(req.Items[a], req.Items[b])
+	})
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var id string
+		err := tx.QueryRowContext(ctx, tx.Rebind(queryInsertReservation),
+			req.UserId,
+			req.OrderRef,
+			fmt.Sprintf("%d seconds", int(req.TTL().Seconds())),
+		).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		productIds := make([]string, 0, len(req.Items))
+
+		for _, i := range order {
+			item := req.Items[i]
+
+			if err := r.reserveItem(ctx, tx, item); err != nil {
+				return &entity.ReservationItemError{Index: i, Err: err}
+			}
+
+			_, err = tx.ExecContext(ctx, tx.Rebind(queryInsertReservationItem), id, item.ProductId, item.VariantId, item.Quantity)
+			if err != nil {
+				return err
+			}
+
+			productIds = append(productIds, item.ProductId)
+		}
+
+		return r.refreshStockSummaries(ctx, tx, productIds)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ReserveStock - Failed to reserve stock")
+		return nil, err
+	}
+
+	return r.GetReservation(ctx, &entity.ReservationRequest{OrderRef: req.OrderRef, UserId: req.UserId})
+}
+
+func (r *productRepository) reserveItem(ctx context.Context, tx *sqlx.Tx, item entity.ReserveStockItem) error {
+	row, err := r.lockStock(ctx, tx, item.ProductId, item.VariantId, false)
+	if err != nil {
+		return err
+	}
+
+	if row.Stock-row.ReservedStock < item.Quantity {
+		return entity.ErrInsufficientStock
+	}
+
+	var (
+		query = queryReserveProductStock
+		id    = item.ProductId
+	)
+	if item.VariantId != nil {
+		query, id = queryReserveVariantStock, *item.VariantId
+	}
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(query), item.Quantity, id)
+	return err
+}
+
+// unreserveItem gives back the reserved quantity of an item, its stock stays untouched.
+func (r *productRepository) unreserveItem(ctx context.Context, tx *sqlx.Tx, item entity.ReserveStockItem) error {
+	var (
+		query = queryReleaseProductStock
+		id    = item.ProductId
+	)
+	if item.VariantId != nil {
+		query, id = queryReleaseVariantStock, *item.VariantId
+	}
+
+	_, err := tx.ExecContext(ctx, tx.Rebind(query), item.Quantity, id)
+	return err
+}
+
+func (r *productRepository) GetReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.ReservationResponse, error) {
+	var resp = new(entity.ReservationResponse)
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(queryGetReservationByOrderRef), req.OrderRef).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetReservation - Failed to get reservation")
+		return nil, err
+	}
+
+	resp.Items = make([]entity.ReserveStockItem, 0)
+	err = r.db.SelectContext(ctx, &resp.Items, r.db.Rebind(queryGetReservationItems), resp.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetReservation - Failed to get reservation items")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// CommitReservation turns the reserved quantities into sold stock, committing twice is a no-op.
+func (r *productRepository) CommitReservation(ctx context.Context, req *entity.ReservationRequest) error {
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var reservation entity.ReservationResponse
+		err := tx.QueryRowxContext(ctx, tx.Rebind(queryLockLiveReservation), req.OrderRef, req.UserId).StructScan(&reservation)
+		if err != nil {
+			return err
+		}
+
+		if reservation.Status == entity.ReservationCommitted {
+			return nil
+		}
+
+		if reservation.ExpiresAt.Before(time.Now()) {
+			return entity.ErrReservationExpired
+		}
+
+		items, err := r.lockReservationItems(ctx, tx, reservation.Id)
+		if err != nil {
+			return err
+		}
+
+		productIds := make([]string, 0, len(items))
+
+		for i, item := range items {
+			if err := r.unreserveItem(ctx, tx, item); err != nil {
+				return err
+			}
+
+			// the reserved quantity was given back above, so the sale only fails on a stock lowered before it was guarded
+			_, err := r.moveStock(ctx, tx, &entity.CreateStockMovementRequest{
+				ProductId: item.ProductId,
+				UserId:    req.UserId,
+				VariantId: item.VariantId,
+				Delta:     -item.Quantity,
+				Reason:    entity.StockSale,
+				Reference: reservation.OrderRef,
+			})
+			if err != nil {
+				return &entity.ReservationItemError{Index: i, Err: err}
+			}
+
+			productIds = append(productIds, item.ProductId)
+		}
+
+		_, err = tx.ExecContext(ctx, tx.Rebind(queryUpdateReservationStatus), entity.ReservationCommitted, reservation.Id)
+		if err != nil {
+			return err
+		}
+
+		return r.refreshStockSummaries(ctx, tx, productIds)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CommitReservation - Failed to commit reservation")
+		return err
+	}
+
+	return nil
+}
+
+func (r *productRepository) ReleaseReservation(ctx context.Context, req *entity.ReservationRequest) error {
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var reservation entity.ReservationResponse
+		err := tx.QueryRowxContext(ctx, tx.Rebind(queryLockLiveReservation), req.OrderRef, req.UserId).StructScan(&reservation)
+		if err != nil {
+			return err
+		}
+
+		if reservation.Status == entity.ReservationCommitted {
+			return entity.ErrReservationCommitted
+		}
+
+		return r.releaseReservation(ctx, tx, reservation.Id, entity.ReservationReleased)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ReleaseReservation - Failed to release reservation")
+		return err
+	}
+
+	return nil
+}
+
+// ExpireReservations releases at most limit reservations past their expiry and returns how many were released.
+func (r *productRepository) ExpireReservations(ctx context.Context, limit int) (int, error) {
+	var ids []string
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		err := tx.SelectContext(ctx, &ids, tx.Rebind(queryLockExpiredReservations), limit)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			if err := r.releaseReservation(ctx, tx, id, entity.ReservationExpired); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("repository::ExpireReservations - Failed to expire reservations")
+		return 0, err
+	}
+
+	return len(ids), nil
+}
+
+// releaseReservation gives the reserved quantities back and closes the reservation with status.
+func (r *productRepository) releaseReservation(ctx context.Context, tx *sqlx.Tx, id, status string) error {
+	items, err := r.lockReservationItems(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	productIds := make([]string, 0, len(items))
+
+	for _, item := range items {
+		if err := r.unreserveItem(ctx, tx, item); err != nil {
+			return err
+		}
+
+		productIds = append(productIds, item.ProductId)
+	}
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(queryUpdateReservationStatus), status, id)
+	if err != nil {
+		return err
+	}
+
+	return r.refreshStockSummaries(ctx, tx, productIds)
+}
+
+// lockReservationItems returns the items of a reservation after locking their products,
+// products are locked before variants everywhere so that stock changes can not deadlock.
+func (r *productRepository) lockReservationItems(ctx context.Context, tx *sqlx.Tx, reservationId string) ([]entity.ReserveStockItem, error) {
+	var items []entity.ReserveStockItem
+
+	err := tx.SelectContext(ctx, &items, tx.Rebind(queryGetReservationItems), reservationId)
+	if err != nil {
+		return nil, err
+	}
+
+	productIds := make([]string, 0, len(items))
+	for _, item := range items {
+		productIds = append(productIds, item.ProductId)
+	}
+
+	var locked []string
+	err = tx.SelectContext(ctx, &locked, tx.Rebind(queryLockProducts), pq.Array(productIds))
+	return items, err
+}
+
+// refreshStockSummaries refreshes the available stock of every product once, in id order.
+func (r *productRepository) refreshStockSummaries(ctx context.Context, tx *sqlx.Tx, productIds []string) error {
+	slices.Sort(productIds)
+
+	for _, productId := range slices.Compact(productIds) {
+		if err := r.refreshVariantSummary(ctx, tx, productId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compareItems orders reservation items by product then variant, the product's own stock first.
+func compareItems(a, b entity.ReserveStockItem) int {
+	if c := cmp.Compare(a.ProductId, b.ProductId); c != 0 {
+		return c
+	}
+
+	switch {
+	case a.VariantId == nil && b.VariantId == nil:
+		return 0
+	case a.VariantId == nil:
+		return -1
+	case b.VariantId == nil:
+		return 1
+	default:
+		return cmp.Compare(*a.VariantId, *b.VariantId)
+	}
+}
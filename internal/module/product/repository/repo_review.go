@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+func (r *productRepository) CreateReview(ctx context.Context, req *entity.CreateReviewRequest) (*entity.CreateReviewResponse, error) {
+	var resp = new(entity.CreateReviewResponse)
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		if err := r.lockProduct(ctx, tx, req.ProductId); err != nil {
+			return err
+		}
+
+		err := tx.QueryRowContext(ctx, tx.Rebind(queryInsertReview),
+			req.ProductId,
+			req.UserId,
+			req.Rating,
+			req.Text,
+			req.ProductId,
+		).Scan(&resp.Id)
+		if err != nil {
+			return err
+		}
+
+		return r.refreshRating(ctx, tx, req.ProductId)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateReview - Failed to create review")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) GetReviews(ctx context.Context, req *entity.ReviewsRequest) (*entity.ReviewsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.ReviewItem
+	}
+
+	var (
+		resp = new(entity.ReviewsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.ReviewItem, 0, req.Paginate)
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(queryGetReviewsByProductId),
+		req.ProductId,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetReviews - Failed to get reviews")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.ReviewItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *productRepository) UpdateReview(ctx context.Context, req *entity.UpdateReviewRequest) (*entity.UpdateReviewResponse, error) {
+	var resp = new(entity.UpdateReviewResponse)
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		if err := r.lockProduct(ctx, tx, req.ProductId); err != nil {
+			return err
+		}
+
+		err := tx.QueryRowContext(ctx, tx.Rebind(queryUpdateReview),
+			req.Rating,
+			req.Text,
+			req.Id,
+			req.ProductId,
+			req.UserId,
+		).Scan(&resp.Id)
+		if err != nil {
+			return err
+		}
+
+		return r.refreshRating(ctx, tx, req.ProductId)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateReview - Failed to update review")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) DeleteReview(ctx context.Context, req *entity.DeleteReviewRequest) error {
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		if err := r.lockProduct(ctx, tx, req.ProductId); err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, tx.Rebind(querySoftDeleteReview), req.Id, req.ProductId, req.UserId)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return sql.ErrNoRows
+		}
+
+		return r.refreshRating(ctx, tx, req.ProductId)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteReview - Failed to delete review")
+		return err
+	}
+
+	return nil
+}
+
+// refreshRating recomputes the rating of a product and rolls it up into the rating of its shop,
+// the caller must hold the lock of the product.
+func (r *productRepository) refreshRating(ctx context.Context, tx *sqlx.Tx, productId string) error {
+	var shopId string
+
+	err := tx.QueryRowContext(ctx, tx.Rebind(queryRefreshProductRating), productId, productId).Scan(&shopId)
+	if err != nil {
+		return err
+	}
+
+	return r.refreshShopRating(ctx, tx, shopId)
+}
+
+// refreshShopRating recomputes the rating of the given shops, it must run whenever
+// a product stops or starts counting towards the rating of its shop.
+func (r *productRepository) refreshShopRating(ctx context.Context, tx *sqlx.Tx, shopIds ...string) error {
+	for _, shopId := range shopIds {
+		if _, err := tx.ExecContext(ctx, tx.Rebind(queryRefreshShopRating), shopId, shopId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
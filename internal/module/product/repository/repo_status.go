@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	outbox "github.com/hilmiikhsan/shopeefun-product-service/internal/module/outbox/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/etag"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+// GetProductShop returns the shop of a product.
+func (r *productRepository) GetProductShop(ctx context.Context, productId string) (string, error) {
+	var shopId string
+
+	err := r.db.QueryRowContext(ctx, r.db.Rebind(queryGetProductShop), productId).Scan(&shopId)
+	if err != nil {
+		log.Error().Err(err).Str("product_id", productId).Msg("repository::GetProductShop - Failed to get product shop")
+		return "", err
+	}
+
+	return shopId, nil
+}
+
+// ChangeStatus moves a product to another status, entity.ErrStatusTransition is returned
+// when the current status can not move to it and entity.ErrStaleVersion when If-Match does not match.
+func (r *productRepository) ChangeStatus(ctx context.Context, req *entity.ChangeStatusRequest) (*entity.ChangeStatusResponse, error) {
+	var resp = &entity.ChangeStatusResponse{Id: req.Id, Status: req.Status}
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var version int
+
+		err := tx.QueryRowContext(ctx, tx.Rebind(queryLockProductStatus), req.Id).Scan(&resp.PreviousStatus, &version)
+		if err != nil {
+			return err
+		}
+
+		if !etag.Match(req.IfMatch, version) {
+			return entity.ErrStaleVersion
+		}
+
+		if !entity.CanTransition(resp.PreviousStatus, req.Status) {
+			return fmt.Errorf("%w from %s to %s", entity.ErrStatusTransition, resp.PreviousStatus, req.Status)
+		}
+
+		err = tx.QueryRowContext(ctx, tx.Rebind(queryUpdateProductStatus), req.Status, req.Id).Scan(&resp.Version)
+		if err != nil {
+			return err
+		}
+
+		return r.addProductEvent(ctx, tx, outbox.ProductUpdated, req.Id)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ChangeStatus - Failed to change product status")
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+func (r *productRepository) CreateStockMovement(ctx context.Context, req *entity.CreateStockMovementRequest) (*entity.StockMovementItem, error) {
+	var resp *entity.StockMovementItem
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		if _, err := r.lockStock(ctx, tx, req.ProductId, req.VariantId, true); err != nil {
+			return err
+		}
+
+		var err error
+		resp, err = r.moveStock(ctx, tx, req)
+		if err != nil {
+			return err
+		}
+
+		return r.refreshVariantSummary(ctx, tx, req.ProductId)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateStockMovement - Failed to create stock movement")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) GetStockMovements(ctx context.Context, req *entity.StockMovementsRequest) (*entity.StockMovementsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.StockMovementItem
+	}
+
+	var (
+		resp = new(entity.StockMovementsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.StockMovementItem, 0, req.Paginate)
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(queryGetStockMovements),
+		req.ProductId,
+		req.VariantId,
+		req.VariantId,
+		req.Reason,
+		req.Reason,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetStockMovements - Failed to get stock movements")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.StockMovementItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+// lockStock locks the product, then the variant when given, and returns the stock of the locked item.
+// Products are always locked before their variants so that stock changes can not deadlock. A product
+// that is not active is only found for its owner.
+func (r *productRepository) lockStock(ctx context.Context, tx *sqlx.Tx, productId string, variantId *string, owner bool) (*stockRow, error) {
+	var (
+		row   stockRow
+		query = queryLockProductStock
+	)
+	if owner {
+		query = queryLockOwnedProductStock
+	}
+
+	err := tx.QueryRowxContext(ctx, tx.Rebind(query), productId).StructScan(&row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrStockItemNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if variantId == nil {
+		if row.HasVariants {
+			return nil, entity.ErrVariantRequired
+		}
+
+		return &row, nil
+	}
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(queryLockVariantStock), *variantId, productId).StructScan(&row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrStockItemNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &row, nil
+}
+
+// recordStockChange records a stock change made through the product or variant endpoints, nothing is
+// recorded when the stock stays the same.
+func (r *productRepository) recordStockChange(ctx context.Context, tx *sqlx.Tx, m *entity.CreateStockMovementRequest) error {
+	if m.Delta == 0 {
+		return nil
+	}
+
+	_, err := r.moveStock(ctx, tx, m)
+	return err
+}
+
+// moveStock applies the delta of a movement and records it in the ledger, it is the only writer of the
+// stock columns. The caller locks the stock first and refreshes the product summary afterward.
+func (r *productRepository) moveStock(ctx context.Context, tx *sqlx.Tx, m *entity.CreateStockMovementRequest) (*entity.StockMovementItem, error) {
+	var (
+		query   = queryMoveProductStock
+		id      = m.ProductId
+		balance int
+	)
+	if m.VariantId != nil {
+		query, id = queryMoveVariantStock, *m.VariantId
+	}
+
+	err := tx.QueryRowContext(ctx, tx.Rebind(query), m.Delta, id, m.Delta).Scan(&balance)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrInsufficientStock
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var item = new(entity.StockMovementItem)
+	err = tx.QueryRowxContext(ctx, tx.Rebind(queryInsertStockMovement),
+		m.ProductId,
+		m.VariantId,
+		m.Delta,
+		balance,
+		m.Reason,
+		m.UserId,
+		m.Reference,
+	).StructScan(item)
+	if err != nil {
+		return nil, err
+	}
+
+	// the initial stock of a new product is part of its product.created event
+	if m.VariantId == nil && m.Reference == entity.InitialStockReference {
+		return item, nil
+	}
+
+	if err := r.addStockEvent(ctx, tx, item.Id); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
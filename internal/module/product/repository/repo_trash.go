@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	outbox "github.com/hilmiikhsan/shopeefun-product-service/internal/module/outbox/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// GetTrashedProductShop returns the shop of a deleted product.
+func (r *productRepository) GetTrashedProductShop(ctx context.Context, productId string) (string, error) {
+	var shopId string
+
+	err := r.db.QueryRowContext(ctx, r.db.Rebind(queryGetTrashedProductShop), productId).Scan(&shopId)
+	if err != nil {
+		log.Error().Err(err).Str("product_id", productId).Msg("repository::GetTrashedProductShop - Failed to get product shop")
+		return "", err
+	}
+
+	return shopId, nil
+}
+
+func (r *productRepository) GetTrashedProducts(ctx context.Context, req *entity.TrashedProductsRequest) (*entity.TrashedProductsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.TrashedProductItem
+	}
+
+	var (
+		resp = new(entity.TrashedProductsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.TrashedProductItem, 0, req.Paginate)
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(queryGetTrashedProducts),
+		req.ShopId,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetTrashedProducts - Failed to get trashed products")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.TrashedProductItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *productRepository) RestoreProduct(ctx context.Context, req *entity.TrashedProductRequest) (*entity.RestoreProductResponse, error) {
+	var resp = new(entity.RestoreProductResponse)
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		err := tx.QueryRowxContext(ctx, tx.Rebind(queryRestoreProduct), req.Id).StructScan(resp)
+		if err != nil {
+			return err
+		}
+
+		if err := r.refreshShopRating(ctx, tx, resp.ShopId); err != nil {
+			return err
+		}
+
+		return r.addProductEvent(ctx, tx, outbox.ProductUpdated, req.Id)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::RestoreProduct - Failed to restore product")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// PurgeProduct deletes a trashed product for good and returns its images, their files are not removed.
+func (r *productRepository) PurgeProduct(ctx context.Context, req *entity.TrashedProductRequest) ([]entity.ImageItem, error) {
+	var images []entity.ImageItem
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var id string
+		if err := tx.QueryRowContext(ctx, tx.Rebind(queryLockTrashedProduct), req.Id).Scan(&id); err != nil {
+			return err
+		}
+
+		var err error
+		images, err = r.purgeProducts(ctx, tx, []string{id})
+		return err
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::PurgeProduct - Failed to purge product")
+		return nil, err
+	}
+
+	return images, nil
+}
+
+// PurgeExpiredProducts deletes for good up to limit products deleted before the given time
+// and returns how many were purged with their images.
+func (r *productRepository) PurgeExpiredProducts(ctx context.Context, before time.Time, limit int) (int, []entity.ImageItem, error) {
+	var (
+		ids    []string
+		images []entity.ImageItem
+	)
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		err := tx.SelectContext(ctx, &ids, tx.Rebind(queryLockExpiredProducts), before, limit)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		images, err = r.purgeProducts(ctx, tx, ids)
+		return err
+	})
+	if err != nil {
+		log.Error().Err(err).Time("before", before).Msg("repository::PurgeExpiredProducts - Failed to purge products")
+		return 0, nil, err
+	}
+
+	return len(ids), images, nil
+}
+
+// purgeProducts deletes locked products, the rows referencing them are deleted by the foreign keys.
+func (r *productRepository) purgeProducts(ctx context.Context, tx *sqlx.Tx, ids []string) ([]entity.ImageItem, error) {
+	images := make([]entity.ImageItem, 0)
+
+	err := tx.SelectContext(ctx, &images, tx.Rebind(queryPurgeProductImages), pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	var shopIds []string
+	if err := tx.SelectContext(ctx, &shopIds, tx.Rebind(queryPurgeProducts), pq.Array(ids)); err != nil {
+		return nil, err
+	}
+
+	slices.Sort(shopIds)
+	if err := r.refreshShopRating(ctx, tx, slices.Compact(shopIds)...); err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
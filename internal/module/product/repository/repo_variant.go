@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	outbox "github.com/hilmiikhsan/shopeefun-product-service/internal/module/outbox/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+func (r *productRepository) CreateVariant(ctx context.Context, req *entity.CreateVariantRequest) (*entity.CreateVariantResponse, error) {
+	var resp = new(entity.CreateVariantResponse)
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		err := tx.QueryRowContext(ctx, tx.Rebind(queryInsertVariant),
+			req.ProductId,
+			req.Sku,
+			req.Options,
+			req.Price,
+			req.ProductId,
+		).Scan(&resp.Id)
+		if err != nil {
+			return err
+		}
+
+		err = r.recordStockChange(ctx, tx, &entity.CreateStockMovementRequest{
+			ProductId: req.ProductId,
+			UserId:    req.UserId,
+			VariantId: &resp.Id,
+			Delta:     req.Stock,
+			Reason:    entity.StockRestock,
+			Reference: entity.InitialStockReference,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = r.recordPriceChange(ctx, tx, req.ProductId, &resp.Id, entity.PriceCreated, "", req.UserId)
+		if err != nil {
+			return err
+		}
+
+		if err := r.refreshVariantSummary(ctx, tx, req.ProductId); err != nil {
+			return err
+		}
+
+		return r.addProductEvent(ctx, tx, outbox.ProductUpdated, req.ProductId)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateVariant - Failed to create variant")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) GetVariants(ctx context.Context, req *entity.VariantsRequest) ([]entity.VariantItem, error) {
+	var resp = make([]entity.VariantItem, 0)
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(queryGetVariantsByProductId), req.ProductId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetVariants - Failed to get variants")
+		return nil, err
+	}
+
+	for i := range resp {
+		resp[i].Label = resp[i].Options.Label()
+		resp[i].EffectivePrice = entity.EffectivePrice(resp[i].Price, resp[i].SalePrice)
+		resp[i].Discount = entity.DiscountPercentage(resp[i].Price, resp[i].EffectivePrice)
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) UpdateVariant(ctx context.Context, req *entity.UpdateVariantRequest) (*entity.UpdateVariantResponse, error) {
+	var resp = new(entity.UpdateVariantResponse)
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		// lock the product before its variants, in the same order as stock reservations
+		var id string
+		if err := tx.QueryRowContext(ctx, tx.Rebind(queryLockProduct), req.ProductId).Scan(&id); err != nil {
+			return err
+		}
+
+		var current stockRow
+		err := tx.QueryRowxContext(ctx, tx.Rebind(queryLockVariantStock), req.Id, req.ProductId).StructScan(&current)
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRowxContext(ctx, tx.Rebind(queryUpdateVariant),
+			req.Sku,
+			req.Options,
+			req.Price,
+			req.Id,
+			req.ProductId,
+		).Scan(&resp.Id)
+		if err != nil {
+			return err
+		}
+
+		err = r.recordStockChange(ctx, tx, &entity.CreateStockMovementRequest{
+			ProductId: req.ProductId,
+			UserId:    req.UserId,
+			VariantId: &req.Id,
+			Delta:     req.Stock - current.Stock,
+			Reason:    entity.StockAdjustment,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = r.recordPriceChange(ctx, tx, req.ProductId, &req.Id, entity.PriceUpdated, "", req.UserId)
+		if err != nil {
+			return err
+		}
+
+		if err := r.refreshVariantSummary(ctx, tx, req.ProductId); err != nil {
+			return err
+		}
+
+		return r.addProductEvent(ctx, tx, outbox.ProductUpdated, req.ProductId)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateVariant - Failed to update variant")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) DeleteVariant(ctx context.Context, req *entity.DeleteVariantRequest) error {
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		// lock the product before its variants, in the same order as stock reservations
+		var id string
+		if err := tx.QueryRowContext(ctx, tx.Rebind(queryLockProduct), req.ProductId).Scan(&id); err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, tx.Rebind(querySoftDeleteVariant), req.Id, req.ProductId)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return sql.ErrNoRows
+		}
+
+		if err := r.refreshVariantSummary(ctx, tx, req.ProductId); err != nil {
+			return err
+		}
+
+		return r.addProductEvent(ctx, tx, outbox.ProductUpdated, req.ProductId)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteVariant - Failed to delete variant")
+		return err
+	}
+
+	return nil
+}
+
+func (r *productRepository) refreshVariantSummary(ctx context.Context, tx *sqlx.Tx, productId string) error {
+	_, err := tx.ExecContext(ctx, tx.Rebind(queryRefreshVariantSummary), productId, productId)
+	return err
+}
@@ -2,44 +2,103 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 
+	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog/log"
 
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/ports"
 	shopEntity "github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/entity"
+	shopService "github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/service"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/etag"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/types"
 )
 
 var _ ports.ProductService = &productService{}
 
 type productService struct {
-	repo ports.ProductRepository
+	repo       ports.ProductRepository
+	shopRepo   ports.ShopRepository
+	storage    ports.ProductStorage
+	validators ports.Validator
 }
 
-func NewProductService(repo ports.ProductRepository) *productService {
+func NewProductService(repo ports.ProductRepository, shopRepo ports.ShopRepository, storage ports.ProductStorage, validators ports.Validator) *productService {
 	return &productService{
-		repo: repo,
+		repo:       repo,
+		shopRepo:   shopRepo,
+		storage:    storage,
+		validators: validators,
 	}
 }
 
 func (s *productService) CreateProduct(ctx context.Context, req *entity.CreateProductRequest) (*entity.CreateProductResponse, error) {
-	return s.repo.CreateProduct(ctx, req)
+	if err := shopService.CheckShopOwner(ctx, s.shopRepo, req.ShopId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.CreateProduct(ctx, req)
+	if err != nil {
+		return nil, referenceError(err)
+	}
+
+	return resp, nil
 }
 
 func (s *productService) GetProduct(ctx context.Context, req *entity.GetProductRequest) (*entity.GetProductResponse, error) {
 	result, err := s.repo.GetProduct(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Product not found"))
+	}
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("service::GetProduct - Failed to get product")
 		return nil, err
 	}
 
+	variants, err := s.repo.GetVariants(ctx, &entity.VariantsRequest{ProductId: result.Id})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("service::GetProduct - Failed to get product variants")
+		return nil, err
+	}
+
+	images, err := s.repo.GetImages(ctx, &entity.ImagesRequest{ProductId: result.Id})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("service::GetProduct - Failed to get product images")
+		return nil, err
+	}
+
+	effectivePrice := entity.EffectivePrice(result.Price, result.SalePrice)
+
 	return &entity.GetProductResponse{
-		Id:          result.Id,
-		Name:        result.Name,
-		Description: result.Description,
-		Category:    result.Category,
-		Price:       result.Price,
-		Stock:       result.Stock,
+		Id:             result.Id,
+		Name:           result.Name,
+		Description:    result.Description,
+		CategoryId:     result.CategoryId,
+		Category:       result.Category,
+		BrandId:        result.BrandId,
+		Brand:          result.Brand,
+		Status:         result.Status,
+		Price:          result.Price,
+		OriginalPrice:  result.Price,
+		EffectivePrice: effectivePrice,
+		Discount:       entity.DiscountPercentage(result.Price, effectivePrice),
+		Stock:          result.Stock,
+		MinPrice:       result.MinPrice,
+		MaxPrice:       result.MaxPrice,
+		TotalStock:     result.TotalStock,
+		AvailableStock: result.AvailableStock,
+		Rating:         result.Rating,
+		ReviewCount:    result.ReviewCount,
+		QuestionCount:  result.QuestionCount,
+		AnswerRate:     entity.AnswerRate(result.QuestionCount, result.AnsweredCount),
+		FavoriteCount:  result.FavoriteCount,
+		IsFavorited:    result.IsFavorited,
+		Version:        result.Version,
+		Variants:       variants,
+		Images:         withImageURLs(images),
 		ShopDetail: shopEntity.ShopItem{
 			Id:     result.ShopId,
 			Name:   result.ShopName,
@@ -49,13 +108,128 @@ func (s *productService) GetProduct(ctx context.Context, req *entity.GetProductR
 }
 
 func (s *productService) GetProducts(ctx context.Context, req *entity.ProductRequest) (*entity.ProductsResponse, error) {
-	return s.repo.GetProducts(ctx, req)
+	if req.Cursor != "" {
+		cursor, err := types.DecodeCursor(req.Cursor, req.SortKey(), entity.SortKind(req.SortKey()))
+		if err != nil {
+			log.Warn().Any("payload", req).Msg("service::GetProducts - Invalid cursor")
+			return nil, errmsg.NewCustomErrors(fiber.StatusBadRequest,
+				errmsg.WithMessage("Invalid cursor"),
+				errmsg.WithErrors("cursor", "cursor is invalid or was made for another sort order."),
+			)
+		}
+
+		req.After = cursor
+	}
+
+	resp, err := s.repo.GetProducts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range resp.Items {
+		resp.Items[i].ImageUrl = imageURL(resp.Items[i].ImageFilename, resp.Items[i].ImageIsPrivate)
+		if !req.Manage {
+			resp.Items[i].ProductManagement = nil
+		}
+	}
+
+	return resp, nil
+}
+
+// GetShopProducts lists the products of a shop. Buyers see the active products in stock,
+// the owner also sees the other statuses, the products out of stock and the management fields.
+func (s *productService) GetShopProducts(ctx context.Context, req *entity.ProductRequest) (*entity.ProductsResponse, error) {
+	ownerId, err := s.shopRepo.GetShopOwner(ctx, req.ShopId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Shop not found"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	req.Manage = req.ViewerId != "" && req.ViewerId == ownerId
+	if !req.Manage {
+		req.InStock = true
+	}
+
+	return s.GetProducts(ctx, req)
+}
+
+// GetMyProducts lists the products of the viewer's own shops in every status, with the management fields.
+func (s *productService) GetMyProducts(ctx context.Context, req *entity.ProductRequest) (*entity.ProductsResponse, error) {
+	req.Mine = true
+	req.Manage = true
+
+	return s.GetProducts(ctx, req)
 }
 
 func (s *productService) UpdateProduct(ctx context.Context, req *entity.UpdateProductRequest) (*entity.UpdateProductResponse, error) {
-	return s.repo.UpdateProduct(ctx, req)
+	if err := req.Fields.Check(entity.UpdateProductFields, "brand_id"); err != nil {
+		return nil, err
+	}
+
+	shopId, err := s.checkProductOwner(ctx, req.Id, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	req.ShopId = shopId
+
+	resp, err := s.repo.UpdateProduct(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Product not found"))
+	}
+	if errors.Is(err, entity.ErrStaleVersion) {
+		return nil, s.staleProduct(ctx, req.Id, req.UserId)
+	}
+	if errors.Is(err, entity.ErrInsufficientStock) {
+		return nil, insufficientStockError("stock")
+	}
+	if err != nil {
+		return nil, referenceError(err)
+	}
+
+	return resp, nil
+}
+
+// staleProduct returns the etag.StaleError of an update made against an old version of a product.
+func (s *productService) staleProduct(ctx context.Context, id, userId string) error {
+	current, err := s.GetProduct(ctx, &entity.GetProductRequest{Id: id, UserId: userId})
+	if err != nil {
+		return err
+	}
+
+	return &etag.StaleError{Version: current.Version, Current: current}
 }
 
 func (s *productService) DeleteProduct(ctx context.Context, req *entity.DeleteProductRequest) error {
-	return s.repo.DeleteProduct(ctx, req)
+	shopId, err := s.checkProductOwner(ctx, req.Id, req.UserId)
+	if err != nil {
+		return err
+	}
+	req.ShopId = shopId
+
+	err = s.repo.DeleteProduct(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Product not found"))
+	}
+
+	return err
+}
+
+// referenceError turns a missing category or brand into a validation error of its field.
+func referenceError(err error) error {
+	switch {
+	case errors.Is(err, entity.ErrCategoryNotFound):
+		return errmsg.NewCustomErrors(fiber.StatusBadRequest,
+			errmsg.WithMessage("Category not found"),
+			errmsg.WithErrors("category_id", "category does not exist."),
+		)
+	case errors.Is(err, entity.ErrBrandNotFound):
+		return errmsg.NewCustomErrors(fiber.StatusBadRequest,
+			errmsg.WithMessage("Brand not found"),
+			errmsg.WithErrors("brand_id", "brand does not exist."),
+		)
+	default:
+		return err
+	}
 }
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	shopService "github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/service"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/xlsx"
+)
+
+// ExportProducts checks that the user owns the shop and returns the function streaming the export,
+// so that the errors found before the first byte is written can still be answered with a status code.
+// The stream runs on its own context since it outlives the request.
+func (s *productService) ExportProducts(ctx context.Context, req *entity.ExportProductsRequest) (func(ctx context.Context, w io.Writer) error, error) {
+	if err := shopService.CheckShopOwner(ctx, s.shopRepo, req.ShopId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, w io.Writer) error {
+		ew, err := newExportWriter(req.Format, w)
+		if err != nil {
+			return err
+		}
+
+		err = s.repo.ExportProducts(ctx, req, func(item *entity.ProductItem) error {
+			item.ImageUrl = imageURL(item.ImageFilename, item.ImageIsPrivate)
+			item.ProductManagement = nil
+			return ew.write(item)
+		})
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("service::ExportProducts - Failed to export products")
+			return err
+		}
+
+		return ew.close()
+	}, nil
+}
+
+// exportWriter writes the products of an export in one format.
+type exportWriter interface {
+	write(item *entity.ProductItem) error
+	close() error
+}
+
+func newExportWriter(format string, w io.Writer) (exportWriter, error) {
+	switch format {
+	case entity.ExportCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(entity.ExportColumns); err != nil {
+			return nil, err
+		}
+		return &csvExportWriter{writer: cw}, nil
+	case entity.ExportNDJSON:
+		return &ndjsonExportWriter{encoder: json.NewEncoder(w)}, nil
+	case entity.ExportXLSX:
+		xw, err := xlsx.NewWriter(w, "Products")
+		if err != nil {
+			return nil, err
+		}
+
+		header := make([]any, 0, len(entity.ExportColumns))
+		for _, column := range entity.ExportColumns {
+			header = append(header, column)
+		}
+		if err := xw.WriteRow(header...); err != nil {
+			return nil, err
+		}
+
+		return &xlsxExportWriter{writer: xw}, nil
+	default:
+		return nil, fmt.Errorf("export format %q is not supported", format)
+	}
+}
+
+type csvExportWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvExportWriter) write(item *entity.ProductItem) error {
+	values := item.ExportRow()
+	record := make([]string, 0, len(values))
+
+	for _, value := range values {
+		record = append(record, csvValue(value))
+	}
+
+	return w.writer.Write(record)
+}
+
+func (w *csvExportWriter) close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func csvValue(value any) string {
+	switch v := value.(type) {
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+type ndjsonExportWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonExportWriter) write(item *entity.ProductItem) error {
+	return w.encoder.Encode(item)
+}
+
+func (w *ndjsonExportWriter) close() error {
+	return nil
+}
+
+type xlsxExportWriter struct {
+	writer *xlsx.Writer
+}
+
+func (w *xlsxExportWriter) write(item *entity.ProductItem) error {
+	return w.writer.WriteRow(item.ExportRow()...)
+}
+
+func (w *xlsxExportWriter) close() error {
+	return w.writer.Close()
+}
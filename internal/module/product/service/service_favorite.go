@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+)
+
+// AddFavorite adds a product the user can see to their wishlist.
+func (s *productService) AddFavorite(ctx context.Context, req *entity.FavoriteRequest) (*entity.FavoriteResponse, error) {
+	if err := s.checkProductVisible(ctx, req.ProductId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.AddFavorite(ctx, req)
+}
+
+func (s *productService) RemoveFavorite(ctx context.Context, req *entity.FavoriteRequest) (*entity.FavoriteResponse, error) {
+	resp, err := s.repo.RemoveFavorite(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Product not found"))
+	}
+
+	return resp, err
+}
+
+func (s *productService) GetFavorites(ctx context.Context, req *entity.FavoritesRequest) (*entity.FavoritesResponse, error) {
+	resp, err := s.repo.GetFavorites(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range resp.Items {
+		resp.Items[i].ImageUrl = imageURL(resp.Items[i].ImageFilename, resp.Items[i].ImageIsPrivate)
+		resp.Items[i].IsFavorited = true
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/storage_manager"
+)
+
+const (
+	maxImageSize          = 5 << 20 // 5 MB
+	signedImageExpiration = 15 * time.Minute
+)
+
+// imageExtensions maps the sniffed content type of an allowed image to its file extension.
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+func (s *productService) UploadImages(ctx context.Context, req *entity.UploadImagesRequest) (*entity.UploadImagesResponse, error) {
+	if _, err := s.checkProductOwner(ctx, req.ProductId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	var (
+		images   = make([]entity.CreateImageRequest, 0, len(req.Images))
+		uploaded = make([]string, 0, len(req.Images))
+		errs     = errmsg.NewCustomErrors(fiber.StatusBadRequest, errmsg.WithMessage("Invalid images"))
+	)
+
+	for i, fh := range req.Images {
+		field := fmt.Sprintf("images[%d]", i)
+
+		if fh.Size > maxImageSize {
+			errs.Add(field, "image must not be larger than 5 MB.")
+			continue
+		}
+
+		contentType, err := storage_manager.DetectContentType(fh)
+		if err != nil {
+			log.Error().Err(err).Str("filename", fh.Filename).Msg("service::UploadImages - Failed to read image")
+			return nil, err
+		}
+
+		ext, ok := imageExtensions[contentType]
+		if !ok {
+			errs.Add(field, "image must be a jpeg, png or webp file.")
+			continue
+		}
+
+		images = append(images, entity.CreateImageRequest{
+			ProductId:   req.ProductId,
+			Filename:    fmt.Sprintf("products/%s/%s%s", req.ProductId, uuid.NewString(), ext),
+			ContentType: contentType,
+			Size:        fh.Size,
+			IsPrivate:   req.IsPrivate,
+		})
+	}
+
+	if errs.HasErrors() {
+		return nil, errs
+	}
+
+	for i, image := range images {
+		key := storage_manager.ObjectKey(image.Filename, image.IsPrivate)
+
+		if err := s.putImage(ctx, key, req.Images[i], image.ContentType); err != nil {
+			log.Error().Err(err).Any("payload", image).Msg("service::UploadImages - Failed to upload image")
+			s.deleteObjects(ctx, uploaded)
+			return nil, err
+		}
+
+		uploaded = append(uploaded, key)
+	}
+
+	items, err := s.repo.CreateImages(ctx, req.ProductId, images)
+	if err != nil {
+		s.deleteObjects(ctx, uploaded)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Product not found"))
+		}
+		return nil, err
+	}
+
+	return &entity.UploadImagesResponse{
+		Items: withImageURLs(items),
+	}, nil
+}
+
+func (s *productService) GetImages(ctx context.Context, req *entity.ImagesRequest) ([]entity.ImageItem, error) {
+	if err := s.checkProductVisible(ctx, req.ProductId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.GetImages(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return withImageURLs(items), nil
+}
+
+func (s *productService) ReorderImages(ctx context.Context, req *entity.ReorderImagesRequest) ([]entity.ImageItem, error) {
+	if _, err := s.checkProductOwner(ctx, req.ProductId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.GetImages(ctx, &entity.ImagesRequest{ProductId: req.ProductId})
+	if err != nil {
+		return nil, err
+	}
+
+	// the new order must mention every image of the product exactly once
+	current := make(map[string]bool, len(items))
+	for _, item := range items {
+		current[item.Id] = true
+	}
+
+	valid := len(req.ImageIds) == len(items)
+	for _, id := range req.ImageIds {
+		valid = valid && current[id]
+	}
+
+	if !valid {
+		log.Warn().Any("payload", req).Msg("service::ReorderImages - Image ids do not match product images")
+		return nil, errmsg.NewCustomErrors(fiber.StatusBadRequest,
+			errmsg.WithMessage("Invalid image order"),
+			errmsg.WithErrors("image_ids", "image ids must contain every image of the product exactly once."),
+		)
+	}
+
+	if err := s.repo.ReorderImages(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return s.GetImages(ctx, &entity.ImagesRequest{ProductId: req.ProductId, UserId: req.UserId})
+}
+
+func (s *productService) SetPrimaryImage(ctx context.Context, req *entity.SetPrimaryImageRequest) error {
+	if _, err := s.checkProductOwner(ctx, req.ProductId, req.UserId); err != nil {
+		return err
+	}
+
+	err := s.repo.SetPrimaryImage(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Image not found"))
+	}
+
+	return err
+}
+
+func (s *productService) DeleteImage(ctx context.Context, req *entity.DeleteImageRequest) error {
+	if _, err := s.checkProductOwner(ctx, req.ProductId, req.UserId); err != nil {
+		return err
+	}
+
+	image, err := s.repo.DeleteImage(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Image not found"))
+	}
+	if err != nil {
+		return err
+	}
+
+	s.deleteObjects(ctx, []string{storage_manager.ObjectKey(image.Filename, image.IsPrivate)})
+
+	return nil
+}
+
+func (s *productService) putImage(ctx context.Context, key string, fh *multipart.FileHeader, contentType string) error {
+	file, err := fh.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return s.storage.Put(ctx, key, file, fh.Size, contentType)
+}
+
+// deleteObjects removes stored files on a best effort basis, failures are only logged.
+func (s *productService) deleteObjects(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			log.Error().Err(err).Str("key", key).Msg("service::deleteObjects - Failed to delete object")
+		}
+	}
+}
+
+func imageURL(filename string, private bool) string {
+	if filename == "" {
+		return ""
+	}
+
+	if private {
+		return storage_manager.GenerateSignedURL(filename, signedImageExpiration)
+	}
+
+	return storage_manager.GeneratePublicURL(filename)
+}
+
+func withImageURLs(items []entity.ImageItem) []entity.ImageItem {
+	for i := range items {
+		items[i].Url = imageURL(items[i].Filename, items[i].IsPrivate)
+	}
+
+	return items
+}
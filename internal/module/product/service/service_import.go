@@ -0,0 +1,344 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	shopService "github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/service"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+)
+
+const (
+	// importBatchSize is how many valid rows are inserted per transaction.
+	importBatchSize = 500
+	// importMaxRowSize is the largest NDJSON row in bytes, a larger row is rejected.
+	importMaxRowSize = 1 << 20
+)
+
+// importColumns are the CSV columns of an import file, named after the json fields of CreateProductRequest.
+var importColumns = []string{"shop_id", "name", "description", "category_id", "brand_id", "price", "stock", "status"}
+
+func (s *productService) ImportProducts(ctx context.Context, req *entity.ImportProductsRequest) (*entity.ImportProductsResponse, error) {
+	rows, err := newImportReader(req.Format, req.File)
+	if err != nil {
+		log.Warn().Err(err).Msg("service::ImportProducts - Invalid import file")
+		return nil, errmsg.NewCustomErrors(fiber.StatusBadRequest,
+			errmsg.WithMessage("Invalid import file"),
+			errmsg.WithErrors("file", err.Error()),
+		)
+	}
+
+	var (
+		resp = &entity.ImportProductsResponse{
+			Accepted: make([]entity.ImportAcceptedRow, 0),
+			Rejected: make([]entity.ImportRejectedRow, 0),
+		}
+		batch = make([]entity.CreateProductRequest, 0, importBatchSize)
+		lines = make([]int, 0, importBatchSize)
+		owned = make(map[string]error) // CheckShopOwner result of every shop of the file
+	)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		results, err := s.repo.ImportProducts(ctx, batch)
+		if err != nil {
+			return err
+		}
+
+		for i, result := range results {
+			if result.Err != nil {
+				log.Warn().Err(result.Err).Int("line", lines[i]).Msg("service::ImportProducts - Row rejected")
+				resp.Rejected = append(resp.Rejected, entity.ImportRejectedRow{Line: lines[i], Errors: importRowErrors(result.Err)})
+				continue
+			}
+
+			resp.Accepted = append(resp.Accepted, entity.ImportAcceptedRow{
+				Line: lines[i],
+				Id:   result.Product.Id,
+				Name: result.Product.Name,
+			})
+		}
+
+		batch, lines = batch[:0], lines[:0]
+		return nil
+	}
+
+	for {
+		line, row, err := rows.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var rowErr *errmsg.CustomError
+		if errors.As(err, &rowErr) {
+			resp.Total++
+			resp.Rejected = append(resp.Rejected, entity.ImportRejectedRow{Line: line, Errors: rowErr.Errors})
+			continue
+		}
+		if err != nil {
+			log.Error().Err(err).Int("line", line).Msg("service::ImportProducts - Failed to read import file")
+			return nil, err
+		}
+
+		resp.Total++
+
+		if row.ShopId == "" {
+			row.ShopId = req.ShopId
+		}
+		row.UserId = req.UserId
+
+		if err := s.validators.Validate(row); err != nil {
+			_, errs := errmsg.Errors(err, row)
+			resp.Rejected = append(resp.Rejected, entity.ImportRejectedRow{Line: line, Errors: errs})
+			continue
+		}
+
+		ownerErr, ok := owned[row.ShopId]
+		if !ok {
+			ownerErr = shopService.CheckShopOwner(ctx, s.shopRepo, row.ShopId, row.UserId)
+			owned[row.ShopId] = ownerErr
+		}
+
+		var shopErr *errmsg.CustomError
+		if errors.As(ownerErr, &shopErr) {
+			resp.Rejected = append(resp.Rejected, entity.ImportRejectedRow{Line: line, Errors: importShopErrors(shopErr)})
+			continue
+		}
+		if ownerErr != nil {
+			return nil, ownerErr
+		}
+
+		batch = append(batch, *row)
+		lines = append(lines, line)
+
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// importRowErrors returns the field errors of a row rejected by the database.
+func importRowErrors(err error) any {
+	_, errs := errmsg.Errors[error](referenceError(err))
+	if m, ok := errs.(map[string][]string); ok && len(m) == 0 {
+		return map[string][]string{"row": {"row could not be imported."}}
+	}
+
+	return errs
+}
+
+// importShopErrors returns the field errors of a row whose shop is missing or owned by another user.
+func importShopErrors(err *errmsg.CustomError) map[string][]string {
+	if err.Code == fiber.StatusForbidden {
+		return map[string][]string{"shop_id": {"shop belongs to another user."}}
+	}
+
+	return map[string][]string{"shop_id": {"shop does not exist."}}
+}
+
+// importReader reads the rows of an import file one by one. A row that can not be decoded is returned
+// with an *errmsg.CustomError holding its field errors, any other error stops the import.
+type importReader interface {
+	next() (line int, row *entity.CreateProductRequest, err error)
+}
+
+func newImportReader(format string, r io.Reader) (importReader, error) {
+	switch format {
+	case entity.ImportCSV:
+		return newCSVImportReader(r)
+	case entity.ImportNDJSON:
+		return newNDJSONImportReader(r), nil
+	default:
+		return nil, fmt.Errorf("format %q is not supported.", format)
+	}
+}
+
+type csvImportReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVImportReader(r io.Reader) (*csvImportReader, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("file is empty.")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !isImportColumn(column) {
+			return nil, fmt.Errorf("column %q is unknown.", column)
+		}
+
+		columns[column] = i
+	}
+
+	return &csvImportReader{reader: reader, columns: columns}, nil
+}
+
+func isImportColumn(column string) bool {
+	for _, c := range importColumns {
+		if c == column {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *csvImportReader) next() (int, *entity.CreateProductRequest, error) {
+	record, err := r.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return 0, nil, err
+	}
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return parseErr.Line, nil, errmsg.NewCustomErrors(fiber.StatusBadRequest,
+			errmsg.WithErrors("row", parseErr.Err.Error()+"."),
+		)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	line, _ := r.reader.FieldPos(0)
+
+	var (
+		row    = new(entity.CreateProductRequest)
+		rowErr = errmsg.NewCustomErrors(fiber.StatusBadRequest)
+	)
+
+	value := func(column string) string {
+		if i, ok := r.columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	row.ShopId = value("shop_id")
+	row.Name = value("name")
+	row.Description = value("description")
+	row.CategoryId = value("category_id")
+	row.Status = value("status")
+
+	if brandId := value("brand_id"); brandId != "" {
+		row.BrandId = &brandId
+	}
+
+	if price := value("price"); price != "" {
+		if row.Price, err = strconv.ParseFloat(price, 64); err != nil {
+			rowErr.Add("price", "price must be a number.")
+		}
+	}
+
+	if stock := value("stock"); stock != "" {
+		if row.Stock, err = strconv.Atoi(stock); err != nil {
+			rowErr.Add("stock", "stock must be an integer.")
+		}
+	}
+
+	if rowErr.HasErrors() {
+		return line, nil, rowErr
+	}
+
+	return line, row, nil
+}
+
+type ndjsonImportReader struct {
+	scanner   *bufio.Scanner
+	line      int
+	oversized bool // the last token is the start of a row over importMaxRowSize
+	skipping  bool // the rest of an oversized row is being discarded
+}
+
+func newNDJSONImportReader(r io.Reader) *ndjsonImportReader {
+	reader := &ndjsonImportReader{scanner: bufio.NewScanner(r)}
+	reader.scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), importMaxRowSize)
+	reader.scanner.Split(reader.split)
+
+	return reader
+}
+
+// split is bufio.ScanLines cutting a row over importMaxRowSize to an empty token and discarding
+// the rest of it, so that the following rows are still read.
+func (r *ndjsonImportReader) split(data []byte, atEOF bool) (int, []byte, error) {
+	if r.skipping {
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			r.skipping = false
+			return i + 1, nil, nil
+		}
+		return len(data), nil, nil
+	}
+
+	advance, token, err := bufio.ScanLines(data, atEOF)
+	if advance == 0 && token == nil && err == nil && len(data) >= importMaxRowSize {
+		r.oversized, r.skipping = true, true
+		return len(data), []byte{}, nil
+	}
+
+	return advance, token, err
+}
+
+func (r *ndjsonImportReader) next() (int, *entity.CreateProductRequest, error) {
+	for r.scanner.Scan() {
+		r.line++
+
+		if r.oversized {
+			r.oversized = false
+			return r.line, nil, errmsg.NewCustomErrors(fiber.StatusBadRequest,
+				errmsg.WithErrors("row", fmt.Sprintf("row is larger than %d bytes.", importMaxRowSize)),
+			)
+		}
+
+		data := bytes.TrimSpace(r.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		row := new(entity.CreateProductRequest)
+		if err := json.Unmarshal(data, row); err != nil {
+			return r.line, nil, errmsg.NewCustomErrors(fiber.StatusBadRequest,
+				errmsg.WithErrors("row", "row is not a valid JSON object."),
+			)
+		}
+
+		return r.line, row, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return r.line, nil, err
+	}
+
+	return r.line, nil, io.EOF
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	shopService "github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/service"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+)
+
+// checkProductOwner returns the shop of a product, a not found error when the product does not exist
+// and a forbidden error when its shop belongs to another user.
+func (s *productService) checkProductOwner(ctx context.Context, productId, userId string) (string, error) {
+	shopId, err := s.repo.GetProductShop(ctx, productId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Product not found"))
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return shopId, shopService.CheckShopOwner(ctx, s.shopRepo, shopId, userId)
+}
+
+// checkProductVisible returns a not found error when the product is not active and its shop
+// does not belong to the user, the same products GetProduct shows.
+func (s *productService) checkProductVisible(ctx context.Context, productId, userId string) error {
+	_, err := s.repo.GetProduct(ctx, &entity.GetProductRequest{Id: productId, UserId: userId})
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Product not found"))
+	}
+
+	return err
+}
+
+// checkTrashedProductOwner is checkProductOwner for a product in the trash. The products of a deleted
+// shop are restored with the shop, so their shop is not found.
+func (s *productService) checkTrashedProductOwner(ctx context.Context, productId, userId string) error {
+	shopId, err := s.repo.GetTrashedProductShop(ctx, productId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Product not found"))
+	}
+	if err != nil {
+		return err
+	}
+
+	return shopService.CheckShopOwner(ctx, s.shopRepo, shopId, userId)
+}
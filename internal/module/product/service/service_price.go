@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+)
+
+// applyBatchSize is how many due price schedules are applied per transaction.
+const applyBatchSize = 100
+
+func (s *productService) CreatePriceSchedule(ctx context.Context, req *entity.CreatePriceScheduleRequest) (*entity.PriceScheduleItem, error) {
+	if _, err := s.checkProductOwner(ctx, req.ProductId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	if !req.EndsAt.After(time.Now()) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusBadRequest,
+			errmsg.WithMessage("Invalid price schedule"),
+			errmsg.WithErrors("ends_at", "ends_at must be in the future."),
+		)
+	}
+
+	resp, err := s.repo.CreatePriceSchedule(ctx, req)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Product or variant not found"))
+	case errors.Is(err, entity.ErrSalePriceNotLower):
+		return nil, errmsg.NewCustomErrors(fiber.StatusBadRequest,
+			errmsg.WithMessage("Invalid price schedule"),
+			errmsg.WithErrors("sale_price", "sale_price must be lower than the price."),
+		)
+	case errors.Is(err, entity.ErrScheduleOverlap):
+		return nil, errmsg.NewCustomErrors(fiber.StatusConflict,
+			errmsg.WithMessage("Price schedule overlaps another schedule"),
+			errmsg.WithErrors("starts_at", "another schedule is planned in this period."),
+		)
+	case err != nil:
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetPriceSchedules lists the planned campaigns of a product, only its owner can see them.
+func (s *productService) GetPriceSchedules(ctx context.Context, req *entity.PriceSchedulesRequest) (*entity.PriceSchedulesResponse, error) {
+	if _, err := s.checkProductOwner(ctx, req.ProductId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetPriceSchedules(ctx, req)
+}
+
+func (s *productService) CancelPriceSchedule(ctx context.Context, req *entity.CancelPriceScheduleRequest) error {
+	if _, err := s.checkProductOwner(ctx, req.ProductId, req.UserId); err != nil {
+		return err
+	}
+
+	err := s.repo.CancelPriceSchedule(ctx, req)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Price schedule not found"))
+	case errors.Is(err, entity.ErrScheduleFinished):
+		return errmsg.NewCustomErrors(fiber.StatusConflict, errmsg.WithMessage("Price schedule already ended or was cancelled"))
+	}
+
+	return err
+}
+
+// ApplyPriceSchedules starts and ends every due price schedule, batch by batch, and returns how many were applied.
+func (s *productService) ApplyPriceSchedules(ctx context.Context) (int, error) {
+	var total int
+
+	for {
+		n, err := s.repo.ApplyPriceSchedules(ctx, applyBatchSize)
+		total += n
+		if err != nil || n < applyBatchSize {
+			return total, err
+		}
+	}
+}
+
+func (s *productService) GetPriceHistory(ctx context.Context, req *entity.PriceHistoryRequest) (*entity.PriceHistoryResponse, error) {
+	if err := s.checkProductVisible(ctx, req.ProductId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetPriceHistory(ctx, req)
+}
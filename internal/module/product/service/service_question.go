@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	shopService "github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/service"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+)
+
+// CreateQuestion asks a question about a product the user can see.
+func (s *productService) CreateQuestion(ctx context.Context, req *entity.CreateQuestionRequest) (*entity.CreateQuestionResponse, error) {
+	if err := s.checkProductVisible(ctx, req.ProductId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.CreateQuestion(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Product not found"))
+	}
+
+	return resp, err
+}
+
+func (s *productService) GetQuestions(ctx context.Context, req *entity.QuestionsRequest) (*entity.QuestionsResponse, error) {
+	if err := s.checkProductVisible(ctx, req.ProductId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetQuestions(ctx, req)
+}
+
+func (s *productService) AnswerQuestion(ctx context.Context, req *entity.AnswerQuestionRequest) (*entity.AnswerQuestionResponse, error) {
+	if _, err := s.checkProductOwner(ctx, req.ProductId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.AnswerQuestion(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Question not found"))
+	}
+
+	return resp, err
+}
+
+// DeleteQuestion lets the buyer delete their question and the owner of the shop delete any question of the product.
+func (s *productService) DeleteQuestion(ctx context.Context, req *entity.DeleteQuestionRequest) error {
+	shopId, err := s.repo.GetProductShop(ctx, req.ProductId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Product not found"))
+	}
+	if err != nil {
+		return err
+	}
+
+	ownerId, err := s.shopRepo.GetShopOwner(ctx, shopId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	req.Owner = ownerId == req.UserId
+
+	err = s.repo.DeleteQuestion(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Question not found"))
+	}
+
+	return err
+}
+
+// GetUnansweredQuestions is the inbox of the owner of a shop.
+func (s *productService) GetUnansweredQuestions(ctx context.Context, req *entity.UnansweredQuestionsRequest) (*entity.UnansweredQuestionsResponse, error) {
+	if err := shopService.CheckShopOwner(ctx, s.shopRepo, req.ShopId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetUnansweredQuestions(ctx, req)
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+)
+
+// expireBatchSize is how many expired reservations are released per transaction.
+const expireBatchSize = 100
+
+func (s *productService) ReserveStock(ctx context.Context, req *entity.ReserveStockRequest) (*entity.ReservationResponse, error) {
+	resp, err := s.repo.ReserveStock(ctx, req)
+	if err != nil {
+		return nil, reservationError(err)
+	}
+
+	return resp, nil
+}
+
+// GetReservation returns the reservation of an order, a forbidden error when another user reserved it.
+func (s *productService) GetReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.ReservationResponse, error) {
+	resp, err := s.repo.GetReservation(ctx, req)
+	if err != nil {
+		return nil, reservationError(err)
+	}
+
+	if resp.UserId != req.UserId {
+		log.Warn().Str("order_ref", req.OrderRef).Str("user_id", req.UserId).Msg("service::GetReservation - User is not the reservation owner")
+		return nil, errmsg.NewCustomErrors(fiber.StatusForbidden, errmsg.WithMessage("Forbidden"))
+	}
+
+	return resp, nil
+}
+
+func (s *productService) CommitReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.ReservationResponse, error) {
+	if _, err := s.GetReservation(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CommitReservation(ctx, req); err != nil {
+		return nil, reservationError(err)
+	}
+
+	return s.GetReservation(ctx, req)
+}
+
+func (s *productService) ReleaseReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.ReservationResponse, error) {
+	if _, err := s.GetReservation(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReleaseReservation(ctx, req); err != nil {
+		return nil, reservationError(err)
+	}
+
+	return s.GetReservation(ctx, req)
+}
+
+// ExpireReservations releases every expired reservation, batch by batch, and returns how many were released.
+func (s *productService) ExpireReservations(ctx context.Context) (int, error) {
+	var total int
+
+	for {
+		n, err := s.repo.ExpireReservations(ctx, expireBatchSize)
+		total += n
+		if err != nil || n < expireBatchSize {
+			return total, err
+		}
+	}
+}
+
+func reservationError(err error) error {
+	var itemErr *entity.ReservationItemError
+	if errors.As(err, &itemErr) {
+		var (
+			field = fmt.Sprintf("items[%d]", itemErr.Index)
+			code  = fiber.StatusBadRequest
+			msg   = "product or variant does not exist."
+		)
+
+		switch {
+		case errors.Is(err, entity.ErrInsufficientStock):
+			code, msg = fiber.StatusConflict, "insufficient stock."
+		case errors.Is(err, entity.ErrVariantRequired):
+			msg = "variant_id is required for products with variants."
+		}
+
+		return errmsg.NewCustomErrors(code,
+			errmsg.WithMessage("Stock can not be reserved"),
+			errmsg.WithErrors(field, msg),
+		)
+	}
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Reservation not found"))
+	case errors.Is(err, entity.ErrReservationExpired):
+		return errmsg.NewCustomErrors(fiber.StatusConflict, errmsg.WithMessage("Reservation expired"))
+	case errors.Is(err, entity.ErrReservationCommitted):
+		return errmsg.NewCustomErrors(fiber.StatusConflict, errmsg.WithMessage("Reservation is already committed"))
+	default:
+		return err
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+)
+
+// CreateReview reviews a product the user can see.
+func (s *productService) CreateReview(ctx context.Context, req *entity.CreateReviewRequest) (*entity.CreateReviewResponse, error) {
+	if err := s.checkProductVisible(ctx, req.ProductId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.CreateReview(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Product not found"))
+	}
+
+	return resp, err
+}
+
+func (s *productService) GetReviews(ctx context.Context, req *entity.ReviewsRequest) (*entity.ReviewsResponse, error) {
+	if err := s.checkProductVisible(ctx, req.ProductId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetReviews(ctx, req)
+}
+
+func (s *productService) UpdateReview(ctx context.Context, req *entity.UpdateReviewRequest) (*entity.UpdateReviewResponse, error) {
+	resp, err := s.repo.UpdateReview(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Review not found"))
+	}
+
+	return resp, err
+}
+
+func (s *productService) DeleteReview(ctx context.Context, req *entity.DeleteReviewRequest) error {
+	err := s.repo.DeleteReview(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Review not found"))
+	}
+
+	return err
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+)
+
+func (s *productService) ChangeStatus(ctx context.Context, req *entity.ChangeStatusRequest) (*entity.ChangeStatusResponse, error) {
+	if _, err := s.checkProductOwner(ctx, req.Id, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.ChangeStatus(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Product not found"))
+	}
+	if errors.Is(err, entity.ErrStaleVersion) {
+		return nil, s.staleProduct(ctx, req.Id, req.UserId)
+	}
+	if errors.Is(err, entity.ErrStatusTransition) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusConflict,
+			errmsg.WithMessage("Invalid status transition"),
+			errmsg.WithErrors("status", err.Error()+"."),
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+)
+
+func (s *productService) CreateStockMovement(ctx context.Context, req *entity.CreateStockMovementRequest) (*entity.StockMovementItem, error) {
+	if _, err := s.checkProductOwner(ctx, req.ProductId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	// a sale only takes stock out, a restock or a return only puts it back
+	if (req.Reason == entity.StockSale && req.Delta > 0) ||
+		((req.Reason == entity.StockRestock || req.Reason == entity.StockReturn) && req.Delta < 0) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusBadRequest,
+			errmsg.WithMessage("Invalid stock movement"),
+			errmsg.WithErrors("delta", "delta sign does not match the reason."),
+		)
+	}
+
+	resp, err := s.repo.CreateStockMovement(ctx, req)
+	switch {
+	case errors.Is(err, entity.ErrStockItemNotFound):
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Product or variant not found"))
+	case errors.Is(err, entity.ErrVariantRequired):
+		return nil, errmsg.NewCustomErrors(fiber.StatusBadRequest,
+			errmsg.WithMessage("Variant required"),
+			errmsg.WithErrors("variant_id", "variant_id is required for products with variants."),
+		)
+	case errors.Is(err, entity.ErrInsufficientStock):
+		return nil, insufficientStockError("delta")
+	case err != nil:
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetStockMovements lists the stock ledger of a product with its actors and references, only its owner can see it.
+func (s *productService) GetStockMovements(ctx context.Context, req *entity.StockMovementsRequest) (*entity.StockMovementsResponse, error) {
+	if _, err := s.checkProductOwner(ctx, req.ProductId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetStockMovements(ctx, req)
+}
+
+// insufficientStockError is the error of a stock change going below the reserved stock, reported on field.
+func insufficientStockError(field string) error {
+	return errmsg.NewCustomErrors(fiber.StatusConflict,
+		errmsg.WithMessage("Insufficient stock"),
+		errmsg.WithErrors(field, "stock can not go below the reserved stock."),
+	)
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	shopService "github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/service"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/storage_manager"
+)
+
+// purgeBatchSize is how many expired products are purged per transaction.
+const purgeBatchSize = 100
+
+func (s *productService) GetTrashedProducts(ctx context.Context, req *entity.TrashedProductsRequest) (*entity.TrashedProductsResponse, error) {
+	if err := shopService.CheckShopOwner(ctx, s.shopRepo, req.ShopId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetTrashedProducts(ctx, req)
+}
+
+func (s *productService) RestoreProduct(ctx context.Context, req *entity.TrashedProductRequest) (*entity.RestoreProductResponse, error) {
+	if err := s.checkTrashedProductOwner(ctx, req.Id, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.RestoreProduct(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Product not found"))
+	}
+
+	return resp, err
+}
+
+func (s *productService) PurgeProduct(ctx context.Context, req *entity.TrashedProductRequest) error {
+	if err := s.checkTrashedProductOwner(ctx, req.Id, req.UserId); err != nil {
+		return err
+	}
+
+	images, err := s.repo.PurgeProduct(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Product not found"))
+	}
+	if err != nil {
+		return err
+	}
+
+	s.deleteObjects(ctx, imageKeys(images))
+
+	return nil
+}
+
+// PurgeTrash purges every product deleted before the given time, batch by batch, and returns how many were purged.
+func (s *productService) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	var total int
+
+	for {
+		n, images, err := s.repo.PurgeExpiredProducts(ctx, before, purgeBatchSize)
+		total += n
+		if err != nil {
+			return total, err
+		}
+
+		s.deleteObjects(ctx, imageKeys(images))
+
+		if n < purgeBatchSize {
+			return total, nil
+		}
+	}
+}
+
+func imageKeys(images []entity.ImageItem) []string {
+	keys := make([]string, 0, len(images))
+
+	for _, image := range images {
+		keys = append(keys, storage_manager.ObjectKey(image.Filename, image.IsPrivate))
+	}
+
+	return keys
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+)
+
+func (s *productService) CreateVariant(ctx context.Context, req *entity.CreateVariantRequest) (*entity.CreateVariantResponse, error) {
+	if _, err := s.checkProductOwner(ctx, req.ProductId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.CreateVariant(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Warn().Any("payload", req).Msg("service::CreateVariant - Product not found")
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Product not found"))
+	}
+
+	return resp, err
+}
+
+func (s *productService) GetVariants(ctx context.Context, req *entity.VariantsRequest) ([]entity.VariantItem, error) {
+	if err := s.checkProductVisible(ctx, req.ProductId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetVariants(ctx, req)
+}
+
+func (s *productService) UpdateVariant(ctx context.Context, req *entity.UpdateVariantRequest) (*entity.UpdateVariantResponse, error) {
+	if _, err := s.checkProductOwner(ctx, req.ProductId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.UpdateVariant(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Warn().Any("payload", req).Msg("service::UpdateVariant - Variant not found")
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Variant not found"))
+	}
+	if errors.Is(err, entity.ErrInsufficientStock) {
+		return nil, insufficientStockError("stock")
+	}
+
+	return resp, err
+}
+
+func (s *productService) DeleteVariant(ctx context.Context, req *entity.DeleteVariantRequest) error {
+	if _, err := s.checkProductOwner(ctx, req.ProductId, req.UserId); err != nil {
+		return err
+	}
+
+	err := s.repo.DeleteVariant(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Warn().Any("payload", req).Msg("service::DeleteVariant - Variant not found")
+		return errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Variant not found"))
+	}
+
+	return err
+}
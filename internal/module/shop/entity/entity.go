@@ -1,6 +1,14 @@
 package entity
 
-import "github.com/hilmiikhsan/shopeefun-product-service/pkg/types"
+import (
+	"errors"
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/patch"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/types"
+)
+
+var ErrStaleVersion = errors.New("shop was modified since the given version")
 
 type CreateShopRequest struct {
 	UserId string `validate:"uuid" db:"user_id"`
@@ -19,9 +27,15 @@ type GetShopRequest struct {
 }
 
 type GetShopResponse struct {
-	Name        string `json:"name" db:"name"`
-	Description string `json:"description" db:"description"`
-	Terms       string `json:"terms" db:"terms"`
+	Id           string    `json:"id" db:"id"`
+	Name         string    `json:"name" db:"name"`
+	Description  string    `json:"description" db:"description"`
+	Terms        string    `json:"terms" db:"terms"`
+	Rating       float64   `json:"rating" db:"rating"`
+	ReviewCount  int       `json:"review_count" db:"review_count"`
+	ProductCount int       `json:"product_count" db:"product_count"` // the active products listed to buyers
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	Version      int       `json:"version" db:"version"` // returned as the ETag header
 }
 
 type DeleteShopRequest struct {
@@ -33,14 +47,22 @@ type DeleteShopRequest struct {
 type UpdateShopRequest struct {
 	UserId string `prop:"user_id" validate:"uuid" db:"user_id"`
 
-	Id          string `params:"id" validate:"uuid" db:"id"`
-	Name        string `json:"name" validate:"required" db:"name"`
-	Description string `json:"description" validate:"required" db:"description"`
-	Terms       string `json:"terms" validate:"required" db:"terms"`
+	// A JSON merge patch, only the fields given in the body are validated and updated, none of them can be null.
+	Id          string  `params:"id" validate:"uuid" db:"id"`
+	Name        *string `json:"name" validate:"omitempty,min=1" db:"name"`
+	Description *string `json:"description" validate:"omitempty,max=255" db:"description"`
+	Terms       *string `json:"terms" validate:"omitempty" db:"terms"`
+
+	Fields  patch.Fields `json:"-" validate:"-"` // the members of the body, set by the handler
+	IfMatch string       `json:"-" validate:"-"` // the If-Match header, the update is refused when the shop changed since
 }
 
+// UpdateShopFields are the members of an UpdateShopRequest patch.
+var UpdateShopFields = []string{"name", "description", "terms"}
+
 type UpdateShopResponse struct {
-	Id string `json:"id" db:"id"`
+	Id      string `json:"id" db:"id"`
+	Version int    `json:"version" db:"version"`
 }
 
 type ShopsRequest struct {
@@ -60,9 +82,9 @@ func (r *ShopsRequest) SetDefault() {
 }
 
 type ShopItem struct {
-	Id     string `json:"id" db:"shop_id"`
-	Name   string `json:"name" db:"shop_name"`
-	Rating int    `json:"rating" db:"shop_rating"`
+	Id     string  `json:"id" db:"shop_id"`
+	Name   string  `json:"name" db:"shop_name"`
+	Rating float64 `json:"rating" db:"shop_rating"`
 }
 
 type ShopsResponse struct {
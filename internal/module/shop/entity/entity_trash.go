@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/types"
+)
+
+// TrashedShopItem is a deleted shop, it is kept in the trash with its products until it is restored,
+// purged by its owner or purged after the retention period.
+type TrashedShopItem struct {
+	Id        string    `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	DeletedAt time.Time `json:"deleted_at" db:"deleted_at"`
+}
+
+type TrashedShopsResponse struct {
+	Items []TrashedShopItem `json:"items"`
+	Meta  types.Meta        `json:"meta"`
+}
+
+// TrashedShopRequest restores or purges a deleted shop.
+type TrashedShopRequest struct {
+	UserId string `prop:"user_id" validate:"uuid" db:"user_id"`
+
+	Id string `params:"id" validate:"uuid" db:"id"`
+}
+
+type RestoreShopResponse struct {
+	Id               string `json:"id" db:"id"`
+	Version          int    `json:"version" db:"version"`
+	RestoredProducts int    `json:"restored_products" db:"-"` // the products deleted with the shop
+}
+
+// ImageFile is a stored product image, the files of a purged shop are removed from the storage.
+type ImageFile struct {
+	Filename  string `db:"filename"`
+	IsPrivate bool   `db:"is_private"`
+}
@@ -0,0 +1,47 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/adapter"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/infrastructure/config"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/ports"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/repository"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/service"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/scheduler"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/storage_manager"
+	"github.com/rs/zerolog/log"
+)
+
+type shopJob struct {
+	service ports.ShopService
+}
+
+func NewShopJob() *shopJob {
+	var (
+		job     = new(shopJob)
+		repo    = repository.NewShopRepository(adapter.Adapters.ShopeefunPostgres)
+		storage = storage_manager.NewS3Storage(adapter.Adapters.ShopeefunStorage, config.Envs.ShopeefunStorage.Bucket)
+		service = service.NewShopService(repo, storage)
+	)
+	job.service = service
+
+	return job
+}
+
+func (j *shopJob) Register(s *scheduler.Scheduler) {
+	s.Every("purge_trashed_shops", time.Hour, j.PurgeTrash)
+}
+
+// PurgeTrash deletes for good the shops that stayed in the trash longer than the retention period.
+func (j *shopJob) PurgeTrash(ctx context.Context) error {
+	before := time.Now().AddDate(0, 0, -config.Envs.Trash.RetentionDays)
+
+	purged, err := j.service.PurgeTrash(ctx, before)
+	if purged > 0 {
+		log.Info().Int("purged", purged).Msg("job::PurgeTrash - Purged trashed shops")
+	}
+
+	return err
+}
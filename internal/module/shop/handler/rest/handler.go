@@ -1,15 +1,21 @@
 package rest
 
 import (
+	"errors"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/adapter"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/infrastructure/config"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/middleware"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/entity"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/ports"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/repository"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/service"
 	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/etag"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/patch"
 	"github.com/hilmiikhsan/shopeefun-product-service/pkg/response"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/storage_manager"
 	"github.com/rs/zerolog/log"
 )
 
@@ -21,7 +27,8 @@ func NewShopHandler() *shopHandler {
 	var (
 		handler = new(shopHandler)
 		repo    = repository.NewShopRepository(adapter.Adapters.ShopeefunPostgres)
-		service = service.NewShopService(repo)
+		storage = storage_manager.NewS3Storage(adapter.Adapters.ShopeefunStorage, config.Envs.ShopeefunStorage.Bucket)
+		service = service.NewShopService(repo, storage)
 	)
 	handler.service = service
 
@@ -34,6 +41,10 @@ func (h *shopHandler) Register(router fiber.Router) {
 	router.Get("/shops/:id", h.GetShop)
 	router.Delete("/shops/:id", middleware.UserIdHeader, h.DeleteShop)
 	router.Patch("/shops/:id", middleware.UserIdHeader, h.UpdateShop)
+
+	router.Get("/trash/shops", middleware.UserIdHeader, h.GetTrashedShops)
+	router.Post("/trash/shops/:id/restore", middleware.UserIdHeader, h.RestoreShop)
+	router.Delete("/trash/shops/:id", middleware.UserIdHeader, h.PurgeShop)
 }
 
 func (h *shopHandler) CreateShop(c *fiber.Ctx) error {
@@ -88,6 +99,8 @@ func (h *shopHandler) GetShop(c *fiber.Ctx) error {
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
+	c.Set(fiber.HeaderETag, etag.Format(resp.Version))
+
 	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
 }
 
@@ -129,8 +142,16 @@ func (h *shopHandler) UpdateShop(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
 	}
 
+	fields, err := patch.Parse(c.Body())
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateShop - Parse merge patch")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.Fields = fields
 	req.UserId = locals.UserId
 	req.Id = c.Params("id")
+	req.IfMatch = c.Get(fiber.HeaderIfMatch)
 
 	if err := validators.Validate(req); err != nil {
 		log.Warn().Err(err).Any("payload", req).Msg("handler::UpdateShop - Validate request body")
@@ -139,11 +160,19 @@ func (h *shopHandler) UpdateShop(c *fiber.Ctx) error {
 	}
 
 	resp, err := h.service.UpdateShop(ctx, req)
+
+	var stale *etag.StaleError
+	if errors.As(err, &stale) {
+		c.Set(fiber.HeaderETag, etag.Format(stale.Version))
+		return c.Status(fiber.StatusPreconditionFailed).JSON(response.Stale(stale))
+	}
 	if err != nil {
 		code, errs := errmsg.Errors[error](err)
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
+	c.Set(fiber.HeaderETag, etag.Format(resp.Version))
+
 	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
 }
 
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/entity"
 )
@@ -9,9 +10,20 @@ import (
 type ShopRepository interface {
 	CreateShop(ctx context.Context, req *entity.CreateShopRequest) (*entity.CreateShopResponse, error)
 	GetShop(ctx context.Context, req *entity.GetShopRequest) (*entity.GetShopResponse, error)
+	GetShopOwner(ctx context.Context, shopId string) (string, error)
 	DeleteShop(ctx context.Context, req *entity.DeleteShopRequest) error
 	UpdateShop(ctx context.Context, req *entity.UpdateShopRequest) (*entity.UpdateShopResponse, error)
 	GetShops(ctx context.Context, req *entity.ShopsRequest) (*entity.ShopsResponse, error)
+
+	GetTrashedShops(ctx context.Context, req *entity.ShopsRequest) (*entity.TrashedShopsResponse, error)
+	RestoreShop(ctx context.Context, req *entity.TrashedShopRequest) (*entity.RestoreShopResponse, error)
+	PurgeShop(ctx context.Context, req *entity.TrashedShopRequest) ([]entity.ImageFile, error)
+	PurgeExpiredShops(ctx context.Context, before time.Time, limit int) (int, []entity.ImageFile, error)
+}
+
+// ShopOwnerRepository reads the owners of the shops, the part of ShopRepository used by CheckShopOwner.
+type ShopOwnerRepository interface {
+	GetShopOwner(ctx context.Context, shopId string) (string, error)
 }
 
 type ShopService interface {
@@ -20,4 +32,14 @@ type ShopService interface {
 	DeleteShop(ctx context.Context, req *entity.DeleteShopRequest) error
 	UpdateShop(ctx context.Context, req *entity.UpdateShopRequest) (*entity.UpdateShopResponse, error)
 	GetShops(ctx context.Context, req *entity.ShopsRequest) (*entity.ShopsResponse, error)
+
+	GetTrashedShops(ctx context.Context, req *entity.ShopsRequest) (*entity.TrashedShopsResponse, error)
+	RestoreShop(ctx context.Context, req *entity.TrashedShopRequest) (*entity.RestoreShopResponse, error)
+	PurgeShop(ctx context.Context, req *entity.TrashedShopRequest) error
+	PurgeTrash(ctx context.Context, before time.Time) (int, error)
+}
+
+// ShopStorage removes the stored files of purged shops, implemented by storage_manager.S3Storage.
+type ShopStorage interface {
+	Delete(ctx context.Context, key string) error
 }
@@ -11,30 +11,54 @@ const (
 	`
 
 	queryGetShopById = `
-		SELECT 
-			name, 
-			description, 
-			terms
+		SELECT
+			id,
+			name,
+			description,
+			terms,
+			rating,
+			review_count,
+			(
+				SELECT COUNT(*)
+				FROM products
+				WHERE shop_id = shops.id AND status = 'active' AND deleted_at IS NULL
+			) as product_count,
+			created_at,
+			version
+		FROM shops
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	queryGetShopOwner = `
+		SELECT user_id
 		FROM shops
-		WHERE id = ?
+		WHERE id = ? AND deleted_at IS NULL
 	`
 
 	querySoftDeleteShop = `
 		UPDATE shops
 		SET 
 			deleted_at = NOW()
-		WHERE id = ? AND user_id = ?
+		WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+		RETURNING deleted_at
 	`
 
+	// queryUpdateShop sets only the columns of the patch, %s is the list of "column = :column," assignments.
 	queryUpdateShop = `
 		UPDATE shops
-		SET 
-			name = ?, 
-			description = ?, 
-			terms = ?, 
+		SET
+			%s
+			version = version + 1,
 			updated_at = NOW()
-		WHERE id = ? AND user_id = ?
-		RETURNING id
+		WHERE id = :id
+		RETURNING id, version
+	`
+
+	queryLockShopVersion = `
+		SELECT version
+		FROM shops
+		WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+		FOR UPDATE
 	`
 
 	queryGetAllShop = `
@@ -0,0 +1,20 @@
+package repository
+
+const (
+	// queryInsertShopEvent writes an event holding the row of the shop as it is after the write.
+	queryInsertShopEvent = `
+		INSERT INTO outbox_events (aggregate_type, aggregate_id, event_type, payload)
+		SELECT CAST(? AS VARCHAR), s.id, CAST(? AS VARCHAR), to_jsonb(s)
+		FROM shops s
+		WHERE s.id = ?
+	`
+
+	// queryInsertProductEvents writes an event per product trashed or restored with its shop.
+	queryInsertProductEvents = `
+		INSERT INTO outbox_events (aggregate_type, aggregate_id, event_type, payload)
+		SELECT CAST(? AS VARCHAR), p.id, CAST(? AS VARCHAR), to_jsonb(p) - 'search_vector'
+		FROM products p
+		WHERE p.id = ANY(CAST(? AS UUID[]))
+		ORDER BY p.id
+	`
+)
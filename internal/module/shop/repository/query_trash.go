@@ -0,0 +1,78 @@
+package repository
+
+const (
+	// queryTrashShopProducts moves the products of a deleted shop to the trash with the deleted_at of the shop,
+	// restoring the shop only restores the products deleted with it.
+	queryTrashShopProducts = `
+		UPDATE products
+		SET
+			deleted_at = ?
+		WHERE shop_id = ? AND deleted_at IS NULL
+		RETURNING id
+	`
+
+	queryGetTrashedShops = `
+		SELECT
+			COUNT(id) OVER() as total_data,
+			id,
+			name,
+			deleted_at
+		FROM shops
+		WHERE user_id = ? AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	queryLockTrashedShop = `
+		SELECT deleted_at
+		FROM shops
+		WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL
+		FOR UPDATE
+	`
+
+	queryRestoreShopProducts = `
+		UPDATE products
+		SET
+			deleted_at = NULL,
+			version = version + 1,
+			updated_at = NOW()
+		WHERE shop_id = ? AND deleted_at = ?
+		RETURNING id
+	`
+
+	queryRestoreShop = `
+		UPDATE shops
+		SET
+			deleted_at = NULL,
+			version = version + 1,
+			updated_at = NOW()
+		WHERE id = ?
+		RETURNING id, version
+	`
+
+	// queryLockExpiredShops picks the shops deleted before the given time, the oldest first.
+	queryLockExpiredShops = `
+		SELECT id
+		FROM shops
+		WHERE deleted_at < ?
+		ORDER BY deleted_at
+		LIMIT ?
+		FOR UPDATE SKIP LOCKED
+	`
+
+	// queryPurgeShopImages returns the image files of the products of the shops, they are removed
+	// from the storage afterward.
+	queryPurgeShopImages = `
+		DELETE FROM product_images
+		WHERE product_id IN (
+			SELECT id FROM products WHERE shop_id = ANY(CAST(? AS UUID[]))
+		)
+		RETURNING filename, is_private
+	`
+
+	// queryPurgeShops also deletes the products and vouchers of the shops.
+	queryPurgeShops = `
+		DELETE FROM shops
+		WHERE id = ANY(CAST(? AS UUID[]))
+	`
+)
@@ -2,9 +2,14 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
+	outbox "github.com/hilmiikhsan/shopeefun-product-service/internal/module/outbox/entity"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/entity"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/ports"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/etag"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
 )
@@ -21,14 +26,43 @@ func NewShopRepository(db *sqlx.DB) *shopRepository {
 	}
 }
 
+func (r *shopRepository) withTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::withTx - Failed to begin transaction")
+		return err
+	}
+
+	if err = fn(tx); err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			log.Error().Err(errRollback).Msg("repository::withTx - Failed to rollback transaction")
+		}
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("repository::withTx - Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
 func (r *shopRepository) CreateShop(ctx context.Context, req *entity.CreateShopRequest) (*entity.CreateShopResponse, error) {
 	var resp = new(entity.CreateShopResponse)
 
-	err := r.db.QueryRowContext(ctx, r.db.Rebind(queryInsertShop),
-		req.UserId,
-		req.Name,
-		req.Description,
-		req.Terms).Scan(&resp.Id)
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		err := tx.QueryRowContext(ctx, tx.Rebind(queryInsertShop),
+			req.UserId,
+			req.Name,
+			req.Description,
+			req.Terms).Scan(&resp.Id)
+		if err != nil {
+			return err
+		}
+
+		return r.addShopEvent(ctx, tx, outbox.ShopCreated, resp.Id)
+	})
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("repository::CreateShop - Failed to create shop")
 		return nil, err
@@ -49,8 +83,40 @@ func (r *shopRepository) GetShop(ctx context.Context, req *entity.GetShopRequest
 	return resp, nil
 }
 
+// GetShopOwner returns the user owning a shop.
+func (r *shopRepository) GetShopOwner(ctx context.Context, shopId string) (string, error) {
+	var userId string
+
+	err := r.db.QueryRowContext(ctx, r.db.Rebind(queryGetShopOwner), shopId).Scan(&userId)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Msg("repository::GetShopOwner - Failed to get shop owner")
+		return "", err
+	}
+
+	return userId, nil
+}
+
+// DeleteShop moves a shop and its products to the trash.
 func (r *shopRepository) DeleteShop(ctx context.Context, req *entity.DeleteShopRequest) error {
-	_, err := r.db.ExecContext(ctx, r.db.Rebind(querySoftDeleteShop), req.Id, req.UserId)
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var deletedAt time.Time
+		err := tx.QueryRowContext(ctx, tx.Rebind(querySoftDeleteShop), req.Id, req.UserId).Scan(&deletedAt)
+		if err != nil {
+			return err
+		}
+
+		var productIds []string
+		err = tx.SelectContext(ctx, &productIds, tx.Rebind(queryTrashShopProducts), deletedAt, req.Id)
+		if err != nil {
+			return err
+		}
+
+		if err := r.addShopEvent(ctx, tx, outbox.ShopDeleted, req.Id); err != nil {
+			return err
+		}
+
+		return r.addProductEvents(ctx, tx, outbox.ProductDeleted, productIds)
+	})
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteShop - Failed to delete shop")
 		return err
@@ -62,12 +128,49 @@ func (r *shopRepository) DeleteShop(ctx context.Context, req *entity.DeleteShopR
 func (r *shopRepository) UpdateShop(ctx context.Context, req *entity.UpdateShopRequest) (*entity.UpdateShopResponse, error) {
 	var resp = new(entity.UpdateShopResponse)
 
-	err := r.db.QueryRowxContext(ctx, r.db.Rebind(queryUpdateShop),
-		req.Name,
-		req.Description,
-		req.Terms,
-		req.Id,
-		req.UserId).Scan(&resp.Id)
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var version int
+		err := tx.QueryRowContext(ctx, tx.Rebind(queryLockShopVersion), req.Id, req.UserId).Scan(&version)
+		if err != nil {
+			return err
+		}
+
+		if !etag.Match(req.IfMatch, version) {
+			return entity.ErrStaleVersion
+		}
+
+		var (
+			sets   = make([]string, 0, len(entity.UpdateShopFields))
+			params = map[string]any{"id": req.Id}
+		)
+		set := func(column string, value any) {
+			sets = append(sets, column+" = :"+column+",")
+			params[column] = value
+		}
+
+		if req.Fields.Has("name") {
+			set("name", req.Name)
+		}
+
+		if req.Fields.Has("description") {
+			set("description", req.Description)
+		}
+
+		if req.Fields.Has("terms") {
+			set("terms", req.Terms)
+		}
+
+		query, args, err := sqlx.Named(fmt.Sprintf(queryUpdateShop, strings.Join(sets, "\n\t\t\t")), params)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.QueryRowxContext(ctx, tx.Rebind(query), args...).StructScan(resp); err != nil {
+			return err
+		}
+
+		return r.addShopEvent(ctx, tx, outbox.ShopUpdated, req.Id)
+	})
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateShop - Failed to update shop")
 		return nil, err
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	outbox "github.com/hilmiikhsan/shopeefun-product-service/internal/module/outbox/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// addShopEvent writes an event of the shop to the outbox in the transaction of the write, once the shop is locked.
+func (r *shopRepository) addShopEvent(ctx context.Context, tx *sqlx.Tx, eventType, shopId string) error {
+	_, err := tx.ExecContext(ctx, tx.Rebind(queryInsertShopEvent), outbox.AggregateShop, eventType, shopId)
+	return err
+}
+
+// addProductEvents writes an event per product of the shop changed by the write.
+func (r *shopRepository) addProductEvents(ctx context.Context, tx *sqlx.Tx, eventType string, productIds []string) error {
+	if len(productIds) == 0 {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, tx.Rebind(queryInsertProductEvents), outbox.AggregateProduct, eventType, pq.Array(productIds))
+	return err
+}
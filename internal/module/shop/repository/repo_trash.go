@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	outbox "github.com/hilmiikhsan/shopeefun-product-service/internal/module/outbox/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+func (r *shopRepository) GetTrashedShops(ctx context.Context, req *entity.ShopsRequest) (*entity.TrashedShopsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.TrashedShopItem
+	}
+
+	var (
+		resp = new(entity.TrashedShopsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.TrashedShopItem, 0, req.Paginate)
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(queryGetTrashedShops),
+		req.UserId,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetTrashedShops - Failed to get trashed shops")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.TrashedShopItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+// RestoreShop restores a trashed shop with the products that were deleted with it.
+func (r *shopRepository) RestoreShop(ctx context.Context, req *entity.TrashedShopRequest) (*entity.RestoreShopResponse, error) {
+	var resp = new(entity.RestoreShopResponse)
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var deletedAt time.Time
+		err := tx.QueryRowContext(ctx, tx.Rebind(queryLockTrashedShop), req.Id, req.UserId).Scan(&deletedAt)
+		if err != nil {
+			return err
+		}
+
+		var productIds []string
+		err = tx.SelectContext(ctx, &productIds, tx.Rebind(queryRestoreShopProducts), req.Id, deletedAt)
+		if err != nil {
+			return err
+		}
+		resp.RestoredProducts = len(productIds)
+
+		err = tx.QueryRowxContext(ctx, tx.Rebind(queryRestoreShop), req.Id).StructScan(resp)
+		if err != nil {
+			return err
+		}
+
+		if err := r.addShopEvent(ctx, tx, outbox.ShopUpdated, req.Id); err != nil {
+			return err
+		}
+
+		return r.addProductEvents(ctx, tx, outbox.ProductUpdated, productIds)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::RestoreShop - Failed to restore shop")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// PurgeShop deletes a trashed shop for good with its products and returns their image files, the files are not removed.
+func (r *shopRepository) PurgeShop(ctx context.Context, req *entity.TrashedShopRequest) ([]entity.ImageFile, error) {
+	var files []entity.ImageFile
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var deletedAt time.Time
+		err := tx.QueryRowContext(ctx, tx.Rebind(queryLockTrashedShop), req.Id, req.UserId).Scan(&deletedAt)
+		if err != nil {
+			return err
+		}
+
+		files, err = r.purgeShops(ctx, tx, []string{req.Id})
+		return err
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::PurgeShop - Failed to purge shop")
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// PurgeExpiredShops deletes for good up to limit shops deleted before the given time
+// and returns how many were purged with the image files of their products.
+func (r *shopRepository) PurgeExpiredShops(ctx context.Context, before time.Time, limit int) (int, []entity.ImageFile, error) {
+	var (
+		ids   []string
+		files []entity.ImageFile
+	)
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		err := tx.SelectContext(ctx, &ids, tx.Rebind(queryLockExpiredShops), before, limit)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		files, err = r.purgeShops(ctx, tx, ids)
+		return err
+	})
+	if err != nil {
+		log.Error().Err(err).Time("before", before).Msg("repository::PurgeExpiredShops - Failed to purge shops")
+		return 0, nil, err
+	}
+
+	return len(ids), files, nil
+}
+
+// purgeShops deletes locked shops, the rows referencing them are deleted by the foreign keys.
+func (r *shopRepository) purgeShops(ctx context.Context, tx *sqlx.Tx, ids []string) ([]entity.ImageFile, error) {
+	files := make([]entity.ImageFile, 0)
+
+	err := tx.SelectContext(ctx, &files, tx.Rebind(queryPurgeShopImages), pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind(queryPurgeShops), pq.Array(ids)); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
@@ -2,20 +2,28 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
 
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/entity"
 	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/ports"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/etag"
 )
 
 var _ ports.ShopService = &shopService{}
 
 type shopService struct {
-	repo ports.ShopRepository
+	repo    ports.ShopRepository
+	storage ports.ShopStorage
 }
 
-func NewShopService(repo ports.ShopRepository) *shopService {
+func NewShopService(repo ports.ShopRepository, storage ports.ShopStorage) *shopService {
 	return &shopService{
-		repo: repo,
+		repo:    repo,
+		storage: storage,
 	}
 }
 
@@ -24,15 +32,42 @@ func (s *shopService) CreateShop(ctx context.Context, req *entity.CreateShopRequ
 }
 
 func (s *shopService) GetShop(ctx context.Context, req *entity.GetShopRequest) (*entity.GetShopResponse, error) {
-	return s.repo.GetShop(ctx, req)
+	resp, err := s.repo.GetShop(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Shop not found"))
+	}
+
+	return resp, err
 }
 
 func (s *shopService) DeleteShop(ctx context.Context, req *entity.DeleteShopRequest) error {
-	return s.repo.DeleteShop(ctx, req)
+	err := s.repo.DeleteShop(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Shop not found"))
+	}
+
+	return err
 }
 
 func (s *shopService) UpdateShop(ctx context.Context, req *entity.UpdateShopRequest) (*entity.UpdateShopResponse, error) {
-	return s.repo.UpdateShop(ctx, req)
+	if err := req.Fields.Check(entity.UpdateShopFields); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.UpdateShop(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Shop not found"))
+	}
+	if errors.Is(err, entity.ErrStaleVersion) {
+		current, err := s.repo.GetShop(ctx, &entity.GetShopRequest{Id: req.Id})
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, &etag.StaleError{Version: current.Version, Current: current}
+	}
+
+	return resp, err
 }
 
 func (s *shopService) GetShops(ctx context.Context, req *entity.ShopsRequest) (*entity.ShopsResponse, error) {
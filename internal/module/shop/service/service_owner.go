@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/ports"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+)
+
+// CheckShopOwner returns a not found error when the shop does not exist and a forbidden error
+// when it belongs to another user. It is shared by the modules managing the resources of a shop.
+func CheckShopOwner(ctx context.Context, repo ports.ShopOwnerRepository, shopId, userId string) error {
+	ownerId, err := repo.GetShopOwner(ctx, shopId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Shop not found"))
+	}
+	if err != nil {
+		return err
+	}
+
+	if ownerId != userId {
+		log.Warn().Str("shop_id", shopId).Str("user_id", userId).Msg("service::CheckShopOwner - User is not the shop owner")
+		return errmsg.NewCustomErrors(fiber.StatusForbidden, errmsg.WithMessage("Forbidden"))
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/storage_manager"
+)
+
+// purgeBatchSize is how many expired shops are purged per transaction.
+const purgeBatchSize = 20
+
+func (s *shopService) GetTrashedShops(ctx context.Context, req *entity.ShopsRequest) (*entity.TrashedShopsResponse, error) {
+	return s.repo.GetTrashedShops(ctx, req)
+}
+
+func (s *shopService) RestoreShop(ctx context.Context, req *entity.TrashedShopRequest) (*entity.RestoreShopResponse, error) {
+	resp, err := s.repo.RestoreShop(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Shop not found"))
+	}
+
+	return resp, err
+}
+
+func (s *shopService) PurgeShop(ctx context.Context, req *entity.TrashedShopRequest) error {
+	files, err := s.repo.PurgeShop(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Shop not found"))
+	}
+	if err != nil {
+		return err
+	}
+
+	s.deleteFiles(ctx, files)
+
+	return nil
+}
+
+// PurgeTrash purges every shop deleted before the given time, batch by batch, and returns how many were purged.
+func (s *shopService) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	var total int
+
+	for {
+		n, files, err := s.repo.PurgeExpiredShops(ctx, before, purgeBatchSize)
+		total += n
+		if err != nil {
+			return total, err
+		}
+
+		s.deleteFiles(ctx, files)
+
+		if n < purgeBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// deleteFiles removes stored files on a best effort basis, failures are only logged.
+func (s *shopService) deleteFiles(ctx context.Context, files []entity.ImageFile) {
+	for _, file := range files {
+		key := storage_manager.ObjectKey(file.Filename, file.IsPrivate)
+		if err := s.storage.Delete(ctx, key); err != nil {
+			log.Error().Err(err).Str("key", key).Msg("service::deleteFiles - Failed to delete object")
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package rest
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/adapter"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/infrastructure/config"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/response"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/storage_manager"
+	"github.com/rs/zerolog/log"
+)
+
+type storageHandler struct {
+	storage *storage_manager.S3Storage
+}
+
+func NewStorageHandler() *storageHandler {
+	return &storageHandler{
+		storage: storage_manager.NewS3Storage(adapter.Adapters.ShopeefunStorage, config.Envs.ShopeefunStorage.Bucket),
+	}
+}
+
+// Register mounts the storage routes, the router must be the "/api" group to match the URLs made by storage_manager.
+func (h *storageHandler) Register(router fiber.Router) {
+	router.Get("/storage/public/*", h.GetPublicFile)
+	router.Get("/storage/private/*", h.GetPrivateFile)
+}
+
+func (h *storageHandler) GetPublicFile(c *fiber.Ctx) error {
+	filename := c.Params("*")
+
+	return h.sendFile(c, storage_manager.ObjectKey(filename, false))
+}
+
+func (h *storageHandler) GetPrivateFile(c *fiber.Ctx) error {
+	filename := c.Params("*")
+
+	if !storage_manager.VerifySignedURL(filename, c.Query("expires"), c.Query("signature")) {
+		log.Warn().Str("filename", filename).Msg("handler::GetPrivateFile - Invalid or expired signature")
+		return c.Status(fiber.StatusForbidden).JSON(response.Error("Invalid or expired signature"))
+	}
+
+	return h.sendFile(c, storage_manager.ObjectKey(filename, true))
+}
+
+func (h *storageHandler) sendFile(c *fiber.Ctx, key string) error {
+	body, contentType, err := h.storage.Get(c.Context(), key)
+	if errors.Is(err, storage_manager.ErrObjectNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(response.Error("File not found"))
+	}
+	if err != nil {
+		log.Error().Err(err).Str("key", key).Msg("handler::sendFile - Failed to get file")
+		return c.Status(fiber.StatusInternalServerError).JSON(response.Error(err))
+	}
+
+	c.Set(fiber.HeaderContentType, contentType)
+
+	return c.SendStream(body)
+}
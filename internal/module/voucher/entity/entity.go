@@ -0,0 +1,222 @@
+package entity
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/types"
+)
+
+var (
+	ErrProductNotFound   = errors.New("eligible product not found in the shop")
+	ErrCategoryNotFound  = errors.New("eligible category not found")
+	ErrVoucherNotActive  = errors.New("voucher is not active")
+	ErrVoucherExhausted  = errors.New("voucher usage limit reached")
+	ErrVoucherNotApplied = errors.New("voucher does not apply to the order")
+	ErrQuoteItemNotFound = errors.New("product or variant not found")
+	ErrVariantRequired   = errors.New("variant is required for products with variants")
+)
+
+// QuoteItemError tells which item of a quote failed.
+type QuoteItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *QuoteItemError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *QuoteItemError) Unwrap() error {
+	return e.Err
+}
+
+// Discount types of a voucher, a percentage of the eligible amount or a fixed amount off it.
+const (
+	DiscountPercentage = "percentage"
+	DiscountFixed      = "fixed"
+)
+
+type CreateVoucherRequest struct {
+	ShopId string `params:"id" validate:"uuid" db:"shop_id"`
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	VoucherFields
+}
+
+// VoucherFields are the fields a shop owner sets on a voucher. A voucher without product_ids
+// nor category_ids applies to every product of the shop.
+type VoucherFields struct {
+	Code              string    `json:"code" validate:"required,min=3,max=50,alphanum" db:"code"` // stored uppercase
+	Name              string    `json:"name" validate:"required,max=100" db:"name"`
+	DiscountType      string    `json:"discount_type" validate:"required,oneof=percentage fixed" db:"discount_type"`
+	DiscountValue     float64   `json:"discount_value" validate:"required,gt=0" db:"discount_value"`
+	MaxDiscount       *float64  `json:"max_discount" validate:"omitempty,gt=0" db:"max_discount"` // cap of a percentage discount
+	MinSpend          float64   `json:"min_spend" validate:"min=0" db:"min_spend"`                // on the eligible items
+	UsageLimit        *int      `json:"usage_limit" validate:"omitempty,min=1" db:"usage_limit"`
+	UsageLimitPerUser *int      `json:"usage_limit_per_user" validate:"omitempty,min=1" db:"usage_limit_per_user"`
+	StartsAt          time.Time `json:"starts_at" validate:"required" db:"starts_at"`
+	EndsAt            time.Time `json:"ends_at" validate:"required,gtfield=StartsAt" db:"ends_at"`
+	ProductIds        []string  `json:"product_ids" validate:"omitempty,max=100,unique_in_slice,dive,uuid" db:"-"`
+	CategoryIds       []string  `json:"category_ids" validate:"omitempty,max=100,unique_in_slice,dive,uuid" db:"-"`
+}
+
+type CreateVoucherResponse struct {
+	Id   string `json:"id" db:"id"`
+	Code string `json:"code" db:"code"`
+}
+
+type GetVoucherRequest struct {
+	ShopId string `params:"id" validate:"uuid" db:"shop_id"`
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id string `params:"voucher_id" validate:"uuid" db:"id"`
+}
+
+type VoucherItem struct {
+	Id                string    `json:"id" db:"id"`
+	ShopId            string    `json:"shop_id" db:"shop_id"`
+	Code              string    `json:"code" db:"code"`
+	Name              string    `json:"name" db:"name"`
+	DiscountType      string    `json:"discount_type" db:"discount_type"`
+	DiscountValue     float64   `json:"discount_value" db:"discount_value"`
+	MaxDiscount       *float64  `json:"max_discount" db:"max_discount"`
+	MinSpend          float64   `json:"min_spend" db:"min_spend"`
+	UsageLimit        *int      `json:"usage_limit" db:"usage_limit"`
+	UsageLimitPerUser *int      `json:"usage_limit_per_user" db:"usage_limit_per_user"`
+	UsedCount         int       `json:"used_count" db:"used_count"`
+	StartsAt          time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt            time.Time `json:"ends_at" db:"ends_at"`
+	ProductIds        []string  `json:"product_ids" db:"-"`
+	CategoryIds       []string  `json:"category_ids" db:"-"`
+}
+
+// Check returns why the voucher can not be used at the given time, nil when it can.
+func (v *VoucherItem) Check(now time.Time) error {
+	if now.Before(v.StartsAt) || !now.Before(v.EndsAt) {
+		return ErrVoucherNotActive
+	}
+
+	if v.UsageLimit != nil && v.UsedCount >= *v.UsageLimit {
+		return ErrVoucherExhausted
+	}
+
+	return nil
+}
+
+// Discount returns the discount of the voucher on an eligible amount, rounded to the cent.
+func (v *VoucherItem) Discount(amount float64) float64 {
+	discount := v.DiscountValue
+	if v.DiscountType == DiscountPercentage {
+		discount = amount * v.DiscountValue / 100
+		if v.MaxDiscount != nil && discount > *v.MaxDiscount {
+			discount = *v.MaxDiscount
+		}
+	}
+
+	discount = math.Min(discount, amount)
+
+	return math.Round(discount*100) / 100
+}
+
+type VouchersRequest struct {
+	ShopId   string `params:"id" validate:"uuid" db:"shop_id"`
+	UserId   string `prop:"user_id" validate:"uuid"`
+	Page     int    `query:"page" validate:"required,min=1"`
+	Paginate int    `query:"paginate" validate:"required,min=1,max=100"`
+}
+
+func (r *VouchersRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type VouchersResponse struct {
+	Items []VoucherItem `json:"items"`
+	Meta  types.Meta    `json:"meta"`
+}
+
+type UpdateVoucherRequest struct {
+	ShopId string `params:"id" validate:"uuid" db:"shop_id"`
+	UserId string `prop:"user_id" validate:"uuid"`
+	Id     string `params:"voucher_id" validate:"uuid" db:"id"`
+
+	VoucherFields
+}
+
+type UpdateVoucherResponse struct {
+	Id   string `json:"id" db:"id"`
+	Code string `json:"code" db:"code"`
+}
+
+type DeleteVoucherRequest struct {
+	ShopId string `params:"id" validate:"uuid" db:"shop_id"`
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id string `params:"voucher_id" validate:"uuid" db:"id"`
+}
+
+// ProductVouchersRequest lists the vouchers a buyer can use now on a product.
+type ProductVouchersRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+}
+
+type QuoteRequest struct {
+	// VoucherIds are the vouchers to use, at most one per shop. When empty,
+	// the voucher giving the largest discount is picked for every shop.
+	VoucherIds []string    `json:"voucher_ids" validate:"omitempty,max=20,unique_in_slice,dive,uuid"`
+	Items      []QuoteItem `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+type QuoteItem struct {
+	ProductId string  `json:"product_id" validate:"uuid"`
+	VariantId *string `json:"variant_id" validate:"omitempty,uuid"`
+	Quantity  int     `json:"quantity" validate:"required,min=1"`
+}
+
+// QuotePrice is the current effective price of a quote item.
+type QuotePrice struct {
+	ProductId   string  `db:"product_id"`
+	VariantId   *string `db:"variant_id"`
+	ShopId      string  `db:"shop_id"`
+	Price       float64 `db:"price"`
+	HasVariants bool    `db:"has_variants"`
+}
+
+// QuoteVoucher is a voucher with the quoted products it is eligible for.
+type QuoteVoucher struct {
+	VoucherItem
+	EligibleProductIds []string `db:"-"`
+}
+
+type QuoteResponse struct {
+	Items    []QuoteLine `json:"items"`
+	Shops    []QuoteShop `json:"shops"`
+	Subtotal float64     `json:"subtotal"`
+	Discount float64     `json:"discount"`
+	Total    float64     `json:"total"`
+}
+
+type QuoteLine struct {
+	ProductId string  `json:"product_id"`
+	VariantId *string `json:"variant_id"`
+	ShopId    string  `json:"shop_id"`
+	Quantity  int     `json:"quantity"`
+	UnitPrice float64 `json:"unit_price"`
+	Subtotal  float64 `json:"subtotal"`
+}
+
+type QuoteShop struct {
+	ShopId      string  `json:"shop_id"`
+	Subtotal    float64 `json:"subtotal"`
+	VoucherId   *string `json:"voucher_id"`
+	VoucherCode *string `json:"voucher_code"`
+	Discount    float64 `json:"discount"`
+	Total       float64 `json:"total"`
+}
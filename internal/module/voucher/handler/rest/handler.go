@@ -0,0 +1,237 @@
+package rest
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/adapter"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/middleware"
+	shopRepository "github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/repository"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/voucher/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/voucher/ports"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/voucher/repository"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/voucher/service"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/response"
+	"github.com/rs/zerolog/log"
+)
+
+type voucherHandler struct {
+	service ports.VoucherService
+}
+
+func NewVoucherHandler() *voucherHandler {
+	var (
+		handler  = new(voucherHandler)
+		repo     = repository.NewVoucherRepository(adapter.Adapters.ShopeefunPostgres)
+		shopRepo = shopRepository.NewShopRepository(adapter.Adapters.ShopeefunPostgres)
+		service  = service.NewVoucherService(repo, shopRepo)
+	)
+	handler.service = service
+
+	return handler
+}
+
+func (h *voucherHandler) Register(router fiber.Router) {
+	router.Get("/shops/:id/vouchers", middleware.UserIdHeader, h.GetVouchers)
+	router.Post("/shops/:id/vouchers", middleware.UserIdHeader, h.CreateVoucher)
+	router.Get("/shops/:id/vouchers/:voucher_id", middleware.UserIdHeader, h.GetVoucher)
+	router.Patch("/shops/:id/vouchers/:voucher_id", middleware.UserIdHeader, h.UpdateVoucher)
+	router.Delete("/shops/:id/vouchers/:voucher_id", middleware.UserIdHeader, h.DeleteVoucher)
+
+	router.Get("/products/:id/vouchers", h.GetProductVouchers)
+	router.Post("/vouchers/quote", h.Quote)
+}
+
+func (h *voucherHandler) CreateVoucher(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.CreateVoucherRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::CreateVoucher - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ShopId = c.Params("id")
+	req.UserId = middleware.GetLocals(c).UserId
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::CreateVoucher - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.CreateVoucher(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, ""))
+}
+
+func (h *voucherHandler) GetVoucher(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.GetVoucherRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	req.ShopId = c.Params("id")
+	req.Id = c.Params("voucher_id")
+	req.UserId = middleware.GetLocals(c).UserId
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetVoucher - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetVoucher(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *voucherHandler) GetVouchers(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.VouchersRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetVouchers - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ShopId = c.Params("id")
+	req.UserId = middleware.GetLocals(c).UserId
+	req.SetDefault()
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetVouchers - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetVouchers(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *voucherHandler) UpdateVoucher(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.UpdateVoucherRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateVoucher - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ShopId = c.Params("id")
+	req.Id = c.Params("voucher_id")
+	req.UserId = middleware.GetLocals(c).UserId
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::UpdateVoucher - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.UpdateVoucher(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *voucherHandler) DeleteVoucher(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.DeleteVoucherRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	req.ShopId = c.Params("id")
+	req.Id = c.Params("voucher_id")
+	req.UserId = middleware.GetLocals(c).UserId
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteVoucher - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.DeleteVoucher(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
+
+func (h *voucherHandler) GetProductVouchers(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.ProductVouchersRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	req.ProductId = c.Params("id")
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetProductVouchers - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetProductVouchers(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *voucherHandler) Quote(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.QuoteRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::Quote - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::Quote - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.Quote(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/voucher/entity"
+)
+
+type VoucherRepository interface {
+	CreateVoucher(ctx context.Context, req *entity.CreateVoucherRequest) (*entity.CreateVoucherResponse, error)
+	GetVoucher(ctx context.Context, req *entity.GetVoucherRequest) (*entity.VoucherItem, error)
+	GetVouchers(ctx context.Context, req *entity.VouchersRequest) (*entity.VouchersResponse, error)
+	UpdateVoucher(ctx context.Context, req *entity.UpdateVoucherRequest) (*entity.UpdateVoucherResponse, error)
+	DeleteVoucher(ctx context.Context, req *entity.DeleteVoucherRequest) error
+	GetProductVouchers(ctx context.Context, req *entity.ProductVouchersRequest) ([]entity.VoucherItem, error)
+	GetQuotePrices(ctx context.Context, items []entity.QuoteItem) ([]entity.QuotePrice, error)
+	GetQuoteVouchersById(ctx context.Context, voucherIds, productIds []string) ([]entity.QuoteVoucher, error)
+	GetLiveQuoteVouchers(ctx context.Context, shopIds, productIds []string) ([]entity.QuoteVoucher, error)
+}
+
+type VoucherService interface {
+	CreateVoucher(ctx context.Context, req *entity.CreateVoucherRequest) (*entity.CreateVoucherResponse, error)
+	GetVoucher(ctx context.Context, req *entity.GetVoucherRequest) (*entity.VoucherItem, error)
+	GetVouchers(ctx context.Context, req *entity.VouchersRequest) (*entity.VouchersResponse, error)
+	UpdateVoucher(ctx context.Context, req *entity.UpdateVoucherRequest) (*entity.UpdateVoucherResponse, error)
+	DeleteVoucher(ctx context.Context, req *entity.DeleteVoucherRequest) error
+	GetProductVouchers(ctx context.Context, req *entity.ProductVouchersRequest) ([]entity.VoucherItem, error)
+	Quote(ctx context.Context, req *entity.QuoteRequest) (*entity.QuoteResponse, error)
+}
+
+// ShopRepository reads the owners of the shops, implemented by the shop repository.
+type ShopRepository interface {
+	GetShopOwner(ctx context.Context, shopId string) (string, error)
+}
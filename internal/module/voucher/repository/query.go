@@ -0,0 +1,205 @@
+package repository
+
+const (
+	queryInsertVoucher = `
+		INSERT INTO vouchers (
+			shop_id,
+			code,
+			name,
+			discount_type,
+			discount_value,
+			max_discount,
+			min_spend,
+			usage_limit,
+			usage_limit_per_user,
+			starts_at,
+			ends_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, code
+	`
+
+	// queryInsertVoucherProducts only inserts the products of the voucher shop, the caller compares
+	// the affected rows with the given ids.
+	queryInsertVoucherProducts = `
+		INSERT INTO voucher_products (voucher_id, product_id)
+		SELECT ?, id
+		FROM products
+		WHERE id = ANY(?::uuid[]) AND shop_id = ? AND deleted_at IS NULL
+	`
+
+	queryInsertVoucherCategories = `
+		INSERT INTO voucher_categories (voucher_id, category_id)
+		SELECT ?, id
+		FROM categories
+		WHERE id = ANY(?::uuid[]) AND deleted_at IS NULL
+	`
+
+	queryDeleteVoucherProducts = `
+		DELETE FROM voucher_products
+		WHERE voucher_id = ?
+	`
+
+	queryDeleteVoucherCategories = `
+		DELETE FROM voucher_categories
+		WHERE voucher_id = ?
+	`
+
+	queryVoucherColumns = `
+			v.id,
+			v.shop_id,
+			v.code,
+			v.name,
+			v.discount_type,
+			v.discount_value,
+			v.max_discount,
+			v.min_spend,
+			v.usage_limit,
+			v.usage_limit_per_user,
+			v.used_count,
+			v.starts_at,
+			v.ends_at`
+
+	// queryVoucherTargets returns the eligible products and categories of the voucher v.
+	queryVoucherTargets = `
+			CAST(ARRAY(
+				SELECT product_id FROM voucher_products WHERE voucher_id = v.id ORDER BY product_id
+			) AS TEXT[]) as product_ids,
+			CAST(ARRAY(
+				SELECT category_id FROM voucher_categories WHERE voucher_id = v.id ORDER BY category_id
+			) AS TEXT[]) as category_ids`
+
+	// queryVoucherEligible tells whether the product p is eligible for the voucher v: every product of
+	// the shop when the voucher lists neither products nor categories, otherwise the listed products
+	// and the products of the listed categories and their subcategories.
+	queryVoucherEligible = `
+			p.shop_id = v.shop_id
+			AND (
+				(
+					NOT EXISTS (SELECT 1 FROM voucher_products WHERE voucher_id = v.id)
+					AND NOT EXISTS (SELECT 1 FROM voucher_categories WHERE voucher_id = v.id)
+				)
+				OR EXISTS (SELECT 1 FROM voucher_products WHERE voucher_id = v.id AND product_id = p.id)
+				OR EXISTS (
+					WITH RECURSIVE ancestors AS (
+						SELECT id, parent_id FROM categories WHERE id = p.category_id
+						UNION ALL
+						SELECT c.id, c.parent_id FROM categories c JOIN ancestors a ON c.id = a.parent_id
+					)
+					SELECT 1
+					FROM voucher_categories vc
+					JOIN ancestors a ON a.id = vc.category_id
+					WHERE vc.voucher_id = v.id
+				)
+			)`
+
+	// queryVoucherLive keeps the vouchers within their validity window and usage limit.
+	queryVoucherLive = `
+			v.deleted_at IS NULL
+			AND v.starts_at <= NOW()
+			AND v.ends_at > NOW()
+			AND (v.usage_limit IS NULL OR v.used_count < v.usage_limit)`
+
+	queryGetVoucher = `
+		SELECT` + queryVoucherColumns + `,` + queryVoucherTargets + `
+		FROM vouchers v
+		WHERE v.id = ? AND v.shop_id = ? AND v.deleted_at IS NULL
+	`
+
+	queryGetVouchers = `
+		SELECT
+			COUNT(v.id) OVER() as total_data,` + queryVoucherColumns + `,` + queryVoucherTargets + `
+		FROM vouchers v
+		WHERE v.shop_id = ? AND v.deleted_at IS NULL
+		ORDER BY v.starts_at DESC, v.id
+		LIMIT ? OFFSET ?
+	`
+
+	queryUpdateVoucher = `
+		UPDATE vouchers
+		SET
+			code = ?,
+			name = ?,
+			discount_type = ?,
+			discount_value = ?,
+			max_discount = ?,
+			min_spend = ?,
+			usage_limit = ?,
+			usage_limit_per_user = ?,
+			starts_at = ?,
+			ends_at = ?,
+			updated_at = NOW()
+		WHERE id = ? AND shop_id = ? AND deleted_at IS NULL
+		RETURNING id, code
+	`
+
+	querySoftDeleteVoucher = `
+		UPDATE vouchers
+		SET
+			deleted_at = NOW()
+		WHERE id = ? AND shop_id = ? AND deleted_at IS NULL
+	`
+
+	queryProductExists = `
+		SELECT EXISTS (
+			SELECT 1 FROM products WHERE id = ? AND status = 'active' AND deleted_at IS NULL
+		)
+	`
+
+	queryGetProductVouchers = `
+		SELECT` + queryVoucherColumns + `,` + queryVoucherTargets + `
+		FROM vouchers v
+		JOIN products p ON p.id = ?
+		WHERE` + queryVoucherLive + `
+			AND` + queryVoucherEligible + `
+		ORDER BY v.ends_at, v.id
+	`
+
+	// queryGetProductQuotePrice and queryGetVariantQuotePrice return the effective price of a quote item,
+	// only the active products can be quoted.
+	queryGetProductQuotePrice = `
+		SELECT
+			id as product_id,
+			NULL as variant_id,
+			shop_id,
+			LEAST(price, sale_price) as price,
+			EXISTS (
+				SELECT 1 FROM product_variants WHERE product_id = products.id AND deleted_at IS NULL
+			) as has_variants
+		FROM products
+		WHERE id = ? AND status = 'active' AND deleted_at IS NULL
+	`
+
+	queryGetVariantQuotePrice = `
+		SELECT
+			p.id as product_id,
+			pv.id as variant_id,
+			p.shop_id,
+			LEAST(pv.price, pv.sale_price) as price,
+			true as has_variants
+		FROM product_variants pv
+		JOIN products p ON p.id = pv.product_id AND p.status = 'active' AND p.deleted_at IS NULL
+		WHERE pv.id = ? AND pv.product_id = ? AND pv.deleted_at IS NULL
+	`
+
+	// queryQuoteVoucherEligibleProducts lists which of the quoted products a voucher applies to.
+	queryQuoteVoucherEligibleProducts = `
+			CAST(ARRAY(
+				SELECT p.id
+				FROM products p
+				WHERE p.id = ANY(?::uuid[]) AND p.deleted_at IS NULL
+				AND` + queryVoucherEligible + `
+			) AS TEXT[]) as eligible_product_ids`
+
+	queryGetQuoteVouchersById = `
+		SELECT` + queryVoucherColumns + `,` + queryQuoteVoucherEligibleProducts + `
+		FROM vouchers v
+		WHERE v.id = ANY(?::uuid[]) AND v.deleted_at IS NULL
+	`
+
+	queryGetLiveQuoteVouchers = `
+		SELECT` + queryVoucherColumns + `,` + queryQuoteVoucherEligibleProducts + `
+		FROM vouchers v
+		WHERE v.shop_id = ANY(?::uuid[])
+			AND` + queryVoucherLive + `
+		ORDER BY v.ends_at, v.id
+	`
+)
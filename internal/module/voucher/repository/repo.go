@@ -0,0 +1,349 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/voucher/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/voucher/ports"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.VoucherRepository = &voucherRepository{}
+
+type voucherRepository struct {
+	db *sqlx.DB
+}
+
+func NewVoucherRepository(db *sqlx.DB) *voucherRepository {
+	return &voucherRepository{
+		db: db,
+	}
+}
+
+// voucherDao scans a voucher with its eligible products and categories.
+type voucherDao struct {
+	entity.VoucherItem
+	ProductIds  pq.StringArray `db:"product_ids"`
+	CategoryIds pq.StringArray `db:"category_ids"`
+}
+
+func (d *voucherDao) item() entity.VoucherItem {
+	item := d.VoucherItem
+	item.ProductIds = []string(d.ProductIds)
+	item.CategoryIds = []string(d.CategoryIds)
+
+	return item
+}
+
+// withTx runs fn inside a transaction, rolling it back when fn returns an error.
+func (r *voucherRepository) withTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::withTx - Failed to begin transaction")
+		return err
+	}
+
+	if err = fn(tx); err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			log.Error().Err(errRollback).Msg("repository::withTx - Failed to rollback transaction")
+		}
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("repository::withTx - Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+func (r *voucherRepository) CreateVoucher(ctx context.Context, req *entity.CreateVoucherRequest) (*entity.CreateVoucherResponse, error) {
+	var resp = new(entity.CreateVoucherResponse)
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		err := tx.QueryRowxContext(ctx, tx.Rebind(queryInsertVoucher),
+			req.ShopId,
+			req.Code,
+			req.Name,
+			req.DiscountType,
+			req.DiscountValue,
+			req.MaxDiscount,
+			req.MinSpend,
+			req.UsageLimit,
+			req.UsageLimitPerUser,
+			req.StartsAt,
+			req.EndsAt,
+		).StructScan(resp)
+		if err != nil {
+			return err
+		}
+
+		return r.insertTargets(ctx, tx, resp.Id, req.ShopId, &req.VoucherFields)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateVoucher - Failed to create voucher")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// insertTargets inserts the eligible products and categories of a voucher, the products must belong to its shop.
+func (r *voucherRepository) insertTargets(ctx context.Context, tx *sqlx.Tx, voucherId, shopId string, fields *entity.VoucherFields) error {
+	if len(fields.ProductIds) > 0 {
+		result, err := tx.ExecContext(ctx, tx.Rebind(queryInsertVoucherProducts), voucherId, pq.Array(fields.ProductIds), shopId)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected != int64(len(fields.ProductIds)) {
+			return entity.ErrProductNotFound
+		}
+	}
+
+	if len(fields.CategoryIds) > 0 {
+		result, err := tx.ExecContext(ctx, tx.Rebind(queryInsertVoucherCategories), voucherId, pq.Array(fields.CategoryIds))
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected != int64(len(fields.CategoryIds)) {
+			return entity.ErrCategoryNotFound
+		}
+	}
+
+	return nil
+}
+
+func (r *voucherRepository) GetVoucher(ctx context.Context, req *entity.GetVoucherRequest) (*entity.VoucherItem, error) {
+	var data voucherDao
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(queryGetVoucher), req.Id, req.ShopId).StructScan(&data)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetVoucher - Failed to get voucher")
+		return nil, err
+	}
+
+	resp := data.item()
+
+	return &resp, nil
+}
+
+func (r *voucherRepository) GetVouchers(ctx context.Context, req *entity.VouchersRequest) (*entity.VouchersResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		voucherDao
+	}
+
+	var (
+		resp = new(entity.VouchersResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.VoucherItem, 0, req.Paginate)
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(queryGetVouchers),
+		req.ShopId,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetVouchers - Failed to get vouchers")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.item())
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+// UpdateVoucher replaces a voucher, its eligible products and categories included.
+func (r *voucherRepository) UpdateVoucher(ctx context.Context, req *entity.UpdateVoucherRequest) (*entity.UpdateVoucherResponse, error) {
+	var resp = new(entity.UpdateVoucherResponse)
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		err := tx.QueryRowxContext(ctx, tx.Rebind(queryUpdateVoucher),
+			req.Code,
+			req.Name,
+			req.DiscountType,
+			req.DiscountValue,
+			req.MaxDiscount,
+			req.MinSpend,
+			req.UsageLimit,
+			req.UsageLimitPerUser,
+			req.StartsAt,
+			req.EndsAt,
+			req.Id,
+			req.ShopId,
+		).StructScan(resp)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, tx.Rebind(queryDeleteVoucherProducts), req.Id); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, tx.Rebind(queryDeleteVoucherCategories), req.Id); err != nil {
+			return err
+		}
+
+		return r.insertTargets(ctx, tx, req.Id, req.ShopId, &req.VoucherFields)
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateVoucher - Failed to update voucher")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *voucherRepository) DeleteVoucher(ctx context.Context, req *entity.DeleteVoucherRequest) error {
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(querySoftDeleteVoucher), req.Id, req.ShopId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteVoucher - Failed to delete voucher")
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteVoucher - Failed to get affected rows")
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetProductVouchers returns the live vouchers a product is eligible for, sql.ErrNoRows when the product is not on sale.
+func (r *voucherRepository) GetProductVouchers(ctx context.Context, req *entity.ProductVouchersRequest) ([]entity.VoucherItem, error) {
+	var exists bool
+
+	err := r.db.QueryRowContext(ctx, r.db.Rebind(queryProductExists), req.ProductId).Scan(&exists)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetProductVouchers - Failed to check product")
+		return nil, err
+	}
+
+	if !exists {
+		return nil, sql.ErrNoRows
+	}
+
+	var data []voucherDao
+
+	err = r.db.SelectContext(ctx, &data, r.db.Rebind(queryGetProductVouchers), req.ProductId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetProductVouchers - Failed to get product vouchers")
+		return nil, err
+	}
+
+	resp := make([]entity.VoucherItem, 0, len(data))
+	for i := range data {
+		resp = append(resp, data[i].item())
+	}
+
+	return resp, nil
+}
+
+// GetQuotePrices returns the effective price of every quote item, in the order of the items.
+func (r *voucherRepository) GetQuotePrices(ctx context.Context, items []entity.QuoteItem) ([]entity.QuotePrice, error) {
+	resp := make([]entity.QuotePrice, 0, len(items))
+
+	for i, item := range items {
+		var (
+			price entity.QuotePrice
+			err   error
+		)
+
+		if item.VariantId == nil {
+			err = r.db.QueryRowxContext(ctx, r.db.Rebind(queryGetProductQuotePrice), item.ProductId).StructScan(&price)
+		} else {
+			err = r.db.QueryRowxContext(ctx, r.db.Rebind(queryGetVariantQuotePrice), *item.VariantId, item.ProductId).StructScan(&price)
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &entity.QuoteItemError{Index: i, Err: entity.ErrQuoteItemNotFound}
+		}
+		if err != nil {
+			log.Error().Err(err).Any("payload", item).Msg("repository::GetQuotePrices - Failed to get quote price")
+			return nil, err
+		}
+
+		if item.VariantId == nil && price.HasVariants {
+			return nil, &entity.QuoteItemError{Index: i, Err: entity.ErrVariantRequired}
+		}
+
+		resp = append(resp, price)
+	}
+
+	return resp, nil
+}
+
+func (r *voucherRepository) GetQuoteVouchersById(ctx context.Context, voucherIds, productIds []string) ([]entity.QuoteVoucher, error) {
+	resp, err := r.getQuoteVouchers(ctx, queryGetQuoteVouchersById, productIds, voucherIds)
+	if err != nil {
+		log.Error().Err(err).Strs("voucher_ids", voucherIds).Msg("repository::GetQuoteVouchersById - Failed to get vouchers")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *voucherRepository) GetLiveQuoteVouchers(ctx context.Context, shopIds, productIds []string) ([]entity.QuoteVoucher, error) {
+	resp, err := r.getQuoteVouchers(ctx, queryGetLiveQuoteVouchers, productIds, shopIds)
+	if err != nil {
+		log.Error().Err(err).Strs("shop_ids", shopIds).Msg("repository::GetLiveQuoteVouchers - Failed to get vouchers")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// getQuoteVouchers runs a quote vouchers query, filtered by the given voucher or shop ids, with the quoted products.
+func (r *voucherRepository) getQuoteVouchers(ctx context.Context, query string, productIds, ids []string) ([]entity.QuoteVoucher, error) {
+	type dao struct {
+		entity.VoucherItem
+		EligibleProductIds pq.StringArray `db:"eligible_product_ids"`
+	}
+
+	var data []dao
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), pq.Array(productIds), pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]entity.QuoteVoucher, 0, len(data))
+	for _, d := range data {
+		resp = append(resp, entity.QuoteVoucher{
+			VoucherItem:        d.VoucherItem,
+			EligibleProductIds: []string(d.EligibleProductIds),
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	shopService "github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/service"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/voucher/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/voucher/ports"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+)
+
+var _ ports.VoucherService = &voucherService{}
+
+type voucherService struct {
+	repo     ports.VoucherRepository
+	shopRepo ports.ShopRepository
+}
+
+func NewVoucherService(repo ports.VoucherRepository, shopRepo ports.ShopRepository) *voucherService {
+	return &voucherService{
+		repo:     repo,
+		shopRepo: shopRepo,
+	}
+}
+
+func (s *voucherService) CreateVoucher(ctx context.Context, req *entity.CreateVoucherRequest) (*entity.CreateVoucherResponse, error) {
+	if err := shopService.CheckShopOwner(ctx, s.shopRepo, req.ShopId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	if err := normalizeFields(&req.VoucherFields); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.CreateVoucher(ctx, req)
+	if err != nil {
+		return nil, targetError(err)
+	}
+
+	return resp, nil
+}
+
+func (s *voucherService) GetVoucher(ctx context.Context, req *entity.GetVoucherRequest) (*entity.VoucherItem, error) {
+	if err := shopService.CheckShopOwner(ctx, s.shopRepo, req.ShopId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.GetVoucher(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Voucher not found"))
+	}
+
+	return resp, err
+}
+
+func (s *voucherService) GetVouchers(ctx context.Context, req *entity.VouchersRequest) (*entity.VouchersResponse, error) {
+	if err := shopService.CheckShopOwner(ctx, s.shopRepo, req.ShopId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetVouchers(ctx, req)
+}
+
+func (s *voucherService) UpdateVoucher(ctx context.Context, req *entity.UpdateVoucherRequest) (*entity.UpdateVoucherResponse, error) {
+	if err := shopService.CheckShopOwner(ctx, s.shopRepo, req.ShopId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	if err := normalizeFields(&req.VoucherFields); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.UpdateVoucher(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Voucher not found"))
+	}
+	if err != nil {
+		return nil, targetError(err)
+	}
+
+	return resp, nil
+}
+
+func (s *voucherService) DeleteVoucher(ctx context.Context, req *entity.DeleteVoucherRequest) error {
+	if err := shopService.CheckShopOwner(ctx, s.shopRepo, req.ShopId, req.UserId); err != nil {
+		return err
+	}
+
+	err := s.repo.DeleteVoucher(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Voucher not found"))
+	}
+
+	return err
+}
+
+func (s *voucherService) GetProductVouchers(ctx context.Context, req *entity.ProductVouchersRequest) ([]entity.VoucherItem, error) {
+	resp, err := s.repo.GetProductVouchers(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Product not found"))
+	}
+
+	return resp, err
+}
+
+// normalizeFields uppercases the voucher code and checks the fields depending on the discount type.
+func normalizeFields(fields *entity.VoucherFields) error {
+	fields.Code = strings.ToUpper(fields.Code)
+
+	if fields.DiscountType == entity.DiscountPercentage && fields.DiscountValue > 100 {
+		return errmsg.NewCustomErrors(fiber.StatusBadRequest,
+			errmsg.WithMessage("Invalid voucher"),
+			errmsg.WithErrors("discount_value", "discount_value must not be greater than 100 for a percentage voucher."),
+		)
+	}
+
+	if fields.DiscountType == entity.DiscountFixed && fields.MaxDiscount != nil {
+		return errmsg.NewCustomErrors(fiber.StatusBadRequest,
+			errmsg.WithMessage("Invalid voucher"),
+			errmsg.WithErrors("max_discount", "max_discount is only allowed for a percentage voucher."),
+		)
+	}
+
+	return nil
+}
+
+func targetError(err error) error {
+	switch {
+	case errors.Is(err, entity.ErrProductNotFound):
+		return errmsg.NewCustomErrors(fiber.StatusBadRequest,
+			errmsg.WithMessage("Invalid voucher"),
+			errmsg.WithErrors("product_ids", "every product must exist in the shop."),
+		)
+	case errors.Is(err, entity.ErrCategoryNotFound):
+		return errmsg.NewCustomErrors(fiber.StatusBadRequest,
+			errmsg.WithMessage("Invalid voucher"),
+			errmsg.WithErrors("category_ids", "every category must exist."),
+		)
+	default:
+		return err
+	}
+}
+
+func quoteItemError(err error) error {
+	var itemErr *entity.QuoteItemError
+	if !errors.As(err, &itemErr) {
+		return err
+	}
+
+	msg := "product or variant does not exist."
+	if errors.Is(err, entity.ErrVariantRequired) {
+		msg = "variant_id is required for products with variants."
+	}
+
+	return errmsg.NewCustomErrors(fiber.StatusBadRequest,
+		errmsg.WithMessage("Order can not be quoted"),
+		errmsg.WithErrors(fmt.Sprintf("items[%d]", itemErr.Index), msg),
+	)
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/voucher/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+)
+
+// Quote prices a list of items at their effective price and applies at most one voucher per shop.
+// The usage limit per user is stored with the voucher, the quote does not know the buyer and skips it.
+func (s *voucherService) Quote(ctx context.Context, req *entity.QuoteRequest) (*entity.QuoteResponse, error) {
+	prices, err := s.repo.GetQuotePrices(ctx, req.Items)
+	if err != nil {
+		return nil, quoteItemError(err)
+	}
+
+	var (
+		resp = &entity.QuoteResponse{
+			Items: make([]entity.QuoteLine, 0, len(req.Items)),
+			Shops: make([]entity.QuoteShop, 0),
+		}
+		shops      = make(map[string]int) // index of a shop in resp.Shops
+		shopIds    = make([]string, 0)
+		productIds = make([]string, 0, len(req.Items))
+	)
+
+	for i, item := range req.Items {
+		price := prices[i]
+		line := entity.QuoteLine{
+			ProductId: item.ProductId,
+			VariantId: item.VariantId,
+			ShopId:    price.ShopId,
+			Quantity:  item.Quantity,
+			UnitPrice: price.Price,
+			Subtotal:  round(price.Price * float64(item.Quantity)),
+		}
+		resp.Items = append(resp.Items, line)
+
+		if _, ok := shops[line.ShopId]; !ok {
+			shops[line.ShopId] = len(resp.Shops)
+			shopIds = append(shopIds, line.ShopId)
+			resp.Shops = append(resp.Shops, entity.QuoteShop{ShopId: line.ShopId})
+		}
+		resp.Shops[shops[line.ShopId]].Subtotal += line.Subtotal
+
+		if !slices.Contains(productIds, line.ProductId) {
+			productIds = append(productIds, line.ProductId)
+		}
+	}
+
+	var vouchers map[string]*entity.QuoteVoucher // voucher picked per shop
+	if len(req.VoucherIds) > 0 {
+		vouchers, err = s.givenVouchers(ctx, req.VoucherIds, productIds, resp.Items, shops)
+	} else {
+		vouchers, err = s.bestVouchers(ctx, shopIds, productIds, resp.Items)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range resp.Shops {
+		shop := &resp.Shops[i]
+		shop.Subtotal = round(shop.Subtotal)
+
+		if voucher, ok := vouchers[shop.ShopId]; ok {
+			shop.VoucherId = &voucher.Id
+			shop.VoucherCode = &voucher.Code
+			shop.Discount = voucher.Discount(eligibleSubtotal(voucher, resp.Items))
+		}
+
+		shop.Total = round(shop.Subtotal - shop.Discount)
+
+		resp.Subtotal += shop.Subtotal
+		resp.Discount += shop.Discount
+	}
+
+	resp.Subtotal = round(resp.Subtotal)
+	resp.Discount = round(resp.Discount)
+	resp.Total = round(resp.Subtotal - resp.Discount)
+
+	return resp, nil
+}
+
+// givenVouchers checks the vouchers chosen by the buyer, every voucher that can not be used is reported
+// under its index in voucher_ids.
+func (s *voucherService) givenVouchers(ctx context.Context, voucherIds, productIds []string, lines []entity.QuoteLine, shops map[string]int) (map[string]*entity.QuoteVoucher, error) {
+	found, err := s.repo.GetQuoteVouchersById(ctx, voucherIds, productIds)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		now      = time.Now()
+		vouchers = make(map[string]*entity.QuoteVoucher, len(found))
+		errs     = errmsg.NewCustomErrors(fiber.StatusBadRequest, errmsg.WithMessage("Voucher can not be used"))
+	)
+
+	for i, id := range voucherIds {
+		field := fmt.Sprintf("voucher_ids[%d]", i)
+
+		idx := slices.IndexFunc(found, func(v entity.QuoteVoucher) bool { return v.Id == id })
+		if idx < 0 {
+			errs.Add(field, "voucher does not exist.")
+			continue
+		}
+		voucher := &found[idx]
+
+		if err := voucher.Check(now); err != nil {
+			errs.Add(field, err.Error()+".")
+			continue
+		}
+
+		if _, ok := shops[voucher.ShopId]; !ok {
+			errs.Add(field, entity.ErrVoucherNotApplied.Error()+".")
+			continue
+		}
+
+		if _, ok := vouchers[voucher.ShopId]; ok {
+			errs.Add(field, "only one voucher can be used per shop.")
+			continue
+		}
+
+		amount := eligibleSubtotal(voucher, lines)
+		if amount == 0 {
+			errs.Add(field, entity.ErrVoucherNotApplied.Error()+".")
+			continue
+		}
+
+		if amount < voucher.MinSpend {
+			errs.Add(field, "minimum spend of "+strconv.FormatFloat(voucher.MinSpend, 'f', -1, 64)+" is not reached.")
+			continue
+		}
+
+		vouchers[voucher.ShopId] = voucher
+	}
+
+	if errs.HasErrors() {
+		return nil, errs
+	}
+
+	return vouchers, nil
+}
+
+// bestVouchers picks for every shop the live voucher giving the largest discount.
+func (s *voucherService) bestVouchers(ctx context.Context, shopIds, productIds []string, lines []entity.QuoteLine) (map[string]*entity.QuoteVoucher, error) {
+	found, err := s.repo.GetLiveQuoteVouchers(ctx, shopIds, productIds)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		vouchers  = make(map[string]*entity.QuoteVoucher, len(shopIds))
+		discounts = make(map[string]float64, len(shopIds))
+	)
+
+	for i := range found {
+		voucher := &found[i]
+
+		amount := eligibleSubtotal(voucher, lines)
+		if amount == 0 || amount < voucher.MinSpend {
+			continue
+		}
+
+		if discount := voucher.Discount(amount); discount > discounts[voucher.ShopId] {
+			vouchers[voucher.ShopId] = voucher
+			discounts[voucher.ShopId] = discount
+		}
+	}
+
+	return vouchers, nil
+}
+
+// eligibleSubtotal sums the lines of the voucher shop the voucher applies to.
+func eligibleSubtotal(voucher *entity.QuoteVoucher, lines []entity.QuoteLine) float64 {
+	var amount float64
+
+	for _, line := range lines {
+		if line.ShopId == voucher.ShopId && slices.Contains(voucher.EligibleProductIds, line.ProductId) {
+			amount += line.Subtotal
+		}
+	}
+
+	return round(amount)
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,173 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/patch"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/types"
+)
+
+// Statuses of a delivery.
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// Retry delays of a failed delivery, doubled after every attempt.
+const (
+	RetryDelay    = 30 * time.Second
+	MaxRetryDelay = 6 * time.Hour
+)
+
+// NextAttemptDelay returns how long a delivery waits after its nth failed attempt, ex: 30s, 1m, 2m, 4m...
+func NextAttemptDelay(attempts int) time.Duration {
+	delay := RetryDelay
+	for i := 1; i < attempts && delay < MaxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, MaxRetryDelay)
+}
+
+type CreateWebhookRequest struct {
+	ShopId string `params:"id" validate:"uuid" db:"shop_id"`
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Url    string   `json:"url" validate:"required,http_url,max=2048" db:"url"`
+	Events []string `json:"events" validate:"required,min=1,max=20,unique_in_slice,dive,oneof=* product.* stock.* shop.* product.created product.updated product.deleted stock.changed shop.created shop.updated shop.deleted" db:"events"`
+	Secret string   `json:"secret" validate:"omitempty,min=16,max=128" db:"secret"` // generated when empty
+}
+
+// CreateWebhookResponse is the only response holding the secret of the webhook.
+type CreateWebhookResponse struct {
+	WebhookItem
+	Secret string `json:"secret" db:"secret"`
+}
+
+type GetWebhookRequest struct {
+	ShopId string `params:"id" validate:"uuid" db:"shop_id"`
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id string `params:"webhook_id" validate:"uuid" db:"id"`
+}
+
+type WebhookItem struct {
+	Id           string     `json:"id" db:"id"`
+	ShopId       string     `json:"shop_id" db:"shop_id"`
+	Url          string     `json:"url" db:"url"`
+	Events       []string   `json:"events" db:"-"`
+	Enabled      bool       `json:"enabled" db:"enabled"`
+	FailureCount int        `json:"failure_count" db:"failure_count"` // consecutive failed attempts
+	DisabledAt   *time.Time `json:"disabled_at" db:"disabled_at"`     // set when the webhook was disabled for its failures
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+type WebhooksRequest struct {
+	ShopId   string `params:"id" validate:"uuid" db:"shop_id"`
+	UserId   string `prop:"user_id" validate:"uuid"`
+	Page     int    `query:"page" validate:"required,min=1"`
+	Paginate int    `query:"paginate" validate:"required,min=1,max=100"`
+}
+
+func (r *WebhooksRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type WebhooksResponse struct {
+	Items []WebhookItem `json:"items"`
+	Meta  types.Meta    `json:"meta"`
+}
+
+type UpdateWebhookRequest struct {
+	ShopId string `params:"id" validate:"uuid" db:"shop_id"`
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	// A JSON merge patch, only the fields given in the body are validated and updated, none of them can be null.
+	// Enabling a webhook resets its failures.
+	Id      string    `params:"webhook_id" validate:"uuid" db:"id"`
+	Url     *string   `json:"url" validate:"omitempty,http_url,max=2048" db:"url"`
+	Events  *[]string `json:"events" validate:"omitempty,min=1,max=20,unique_in_slice,dive,oneof=* product.* stock.* shop.* product.created product.updated product.deleted stock.changed shop.created shop.updated shop.deleted" db:"events"`
+	Secret  *string   `json:"secret" validate:"omitempty,min=16,max=128" db:"secret"`
+	Enabled *bool     `json:"enabled" db:"enabled"`
+
+	Fields patch.Fields `json:"-" validate:"-"` // the members of the body, set by the handler
+}
+
+// UpdateWebhookFields are the members of an UpdateWebhookRequest patch.
+var UpdateWebhookFields = []string{"url", "events", "secret", "enabled"}
+
+type DeleteWebhookRequest struct {
+	ShopId string `params:"id" validate:"uuid" db:"shop_id"`
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id string `params:"webhook_id" validate:"uuid" db:"id"`
+}
+
+type DeliveriesRequest struct {
+	ShopId    string `params:"id" validate:"uuid" db:"shop_id"`
+	UserId    string `prop:"user_id" validate:"uuid"`
+	WebhookId string `params:"webhook_id" validate:"uuid" db:"webhook_id"`
+	Status    string `query:"status" validate:"omitempty,oneof=pending succeeded failed" db:"status"`
+	Page      int    `query:"page" validate:"required,min=1"`
+	Paginate  int    `query:"paginate" validate:"required,min=1,max=100"`
+}
+
+func (r *DeliveriesRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type DeliveryItem struct {
+	Id             string          `json:"id" db:"id"`
+	EventId        string          `json:"event_id" db:"event_id"`
+	EventType      string          `json:"event_type" db:"event_type"`
+	Payload        json.RawMessage `json:"payload" db:"payload"`
+	Status         string          `json:"status" db:"status"`
+	Attempts       int             `json:"attempts" db:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at" db:"next_attempt_at"` // nil once the delivery is finished
+	ResponseStatus *int            `json:"response_status" db:"response_status"`
+	LastError      *string         `json:"last_error" db:"last_error"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at" db:"delivered_at"`
+}
+
+type DeliveriesResponse struct {
+	Items []DeliveryItem `json:"items"`
+	Meta  types.Meta     `json:"meta"`
+}
+
+// Delivery is a due delivery with the endpoint it is sent to.
+type Delivery struct {
+	Id        string `db:"id"`
+	WebhookId string `db:"webhook_id"`
+	EventId   string `db:"event_id"`
+	EventType string `db:"event_type"`
+	Payload   []byte `db:"payload"`
+	Attempts  int    `db:"attempts"`
+	Url       string `db:"url"`
+	Secret    string `db:"secret"`
+}
+
+// DeliveryResult is the outcome of an attempt of a delivery.
+type DeliveryResult struct {
+	Id             string
+	WebhookId      string
+	ResponseStatus int    // 0 when no response was received
+	Error          string // empty when the delivery succeeded
+	Failed         bool   // no attempt is left
+	NextAttemptAt  time.Time
+}
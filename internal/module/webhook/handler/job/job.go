@@ -0,0 +1,70 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/adapter"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/infrastructure/config"
+	shopRepository "github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/repository"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/webhook/ports"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/webhook/repository"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/webhook/service"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/scheduler"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/webhook"
+	"github.com/rs/zerolog/log"
+)
+
+type webhookJob struct {
+	service ports.WebhookService
+}
+
+func NewWebhookJob() *webhookJob {
+	var (
+		job      = new(webhookJob)
+		envs     = config.Envs.Webhook
+		repo     = repository.NewWebhookRepository(adapter.Adapters.ShopeefunPostgres)
+		shopRepo = shopRepository.NewShopRepository(adapter.Adapters.ShopeefunPostgres)
+		sender   = webhook.NewClient(time.Duration(envs.Timeout)*time.Second, envs.AllowPrivateNetworks, "Shopeefun-Webhooks/1.0")
+		service  = service.NewWebhookService(repo, shopRepo, sender, envs.MaxAttempts, envs.DisableAfter)
+	)
+	job.service = service
+
+	return job
+}
+
+func (j *webhookJob) Register(s *scheduler.Scheduler) {
+	s.Every("dispatch_webhook_events", time.Second, j.Dispatch)
+	s.Every("deliver_webhooks", 5*time.Second, j.Deliver)
+	s.Every("purge_webhook_deliveries", time.Hour, j.PurgeDeliveries)
+}
+
+func (j *webhookJob) Dispatch(ctx context.Context) error {
+	deliveries, err := j.service.Dispatch(ctx)
+	if deliveries > 0 {
+		log.Info().Int("deliveries", deliveries).Msg("job::Dispatch - Created webhook deliveries")
+	}
+
+	return err
+}
+
+func (j *webhookJob) Deliver(ctx context.Context) error {
+	succeeded, failed, err := j.service.Deliver(ctx)
+	if succeeded > 0 || failed > 0 {
+		log.Info().Int("succeeded", succeeded).Int("failed", failed).Msg("job::Deliver - Sent webhook deliveries")
+	}
+
+	return err
+}
+
+// PurgeDeliveries deletes the finished deliveries older than the retention period.
+func (j *webhookJob) PurgeDeliveries(ctx context.Context) error {
+	before := time.Now().AddDate(0, 0, -config.Envs.Webhook.RetentionDays)
+
+	purged, err := j.service.PurgeDeliveries(ctx, before)
+	if purged > 0 {
+		log.Info().Int("purged", purged).Msg("job::PurgeDeliveries - Purged webhook deliveries")
+	}
+
+	return err
+}
@@ -0,0 +1,232 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/adapter"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/infrastructure/config"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/middleware"
+	shopRepository "github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/repository"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/webhook/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/webhook/ports"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/webhook/repository"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/webhook/service"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/patch"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/response"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/webhook"
+	"github.com/rs/zerolog/log"
+)
+
+type webhookHandler struct {
+	service ports.WebhookService
+}
+
+func NewWebhookHandler() *webhookHandler {
+	var (
+		handler  = new(webhookHandler)
+		envs     = config.Envs.Webhook
+		repo     = repository.NewWebhookRepository(adapter.Adapters.ShopeefunPostgres)
+		shopRepo = shopRepository.NewShopRepository(adapter.Adapters.ShopeefunPostgres)
+		sender   = webhook.NewClient(time.Duration(envs.Timeout)*time.Second, envs.AllowPrivateNetworks, "Shopeefun-Webhooks/1.0")
+		service  = service.NewWebhookService(repo, shopRepo, sender, envs.MaxAttempts, envs.DisableAfter)
+	)
+	handler.service = service
+
+	return handler
+}
+
+func (h *webhookHandler) Register(router fiber.Router) {
+	router.Get("/shops/:id/webhooks", middleware.UserIdHeader, h.GetWebhooks)
+	router.Post("/shops/:id/webhooks", middleware.UserIdHeader, h.CreateWebhook)
+	router.Get("/shops/:id/webhooks/:webhook_id", middleware.UserIdHeader, h.GetWebhook)
+	router.Patch("/shops/:id/webhooks/:webhook_id", middleware.UserIdHeader, h.UpdateWebhook)
+	router.Delete("/shops/:id/webhooks/:webhook_id", middleware.UserIdHeader, h.DeleteWebhook)
+	router.Get("/shops/:id/webhooks/:webhook_id/deliveries", middleware.UserIdHeader, h.GetDeliveries)
+}
+
+func (h *webhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.CreateWebhookRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::CreateWebhook - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ShopId = c.Params("id")
+	req.UserId = middleware.GetLocals(c).UserId
+
+	if err := validators.Validate(req); err != nil {
+		// the payload is not logged, it holds the secret
+		log.Warn().Err(err).Str("shop_id", req.ShopId).Msg("handler::CreateWebhook - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.CreateWebhook(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, ""))
+}
+
+func (h *webhookHandler) GetWebhook(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.GetWebhookRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	req.ShopId = c.Params("id")
+	req.Id = c.Params("webhook_id")
+	req.UserId = middleware.GetLocals(c).UserId
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetWebhook - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetWebhook(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *webhookHandler) GetWebhooks(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.WebhooksRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetWebhooks - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ShopId = c.Params("id")
+	req.UserId = middleware.GetLocals(c).UserId
+	req.SetDefault()
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetWebhooks - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetWebhooks(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *webhookHandler) UpdateWebhook(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.UpdateWebhookRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateWebhook - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	fields, err := patch.Parse(c.Body())
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateWebhook - Parse merge patch")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.Fields = fields
+	req.ShopId = c.Params("id")
+	req.Id = c.Params("webhook_id")
+	req.UserId = middleware.GetLocals(c).UserId
+
+	if err := validators.Validate(req); err != nil {
+		// the payload is not logged, it may hold the secret
+		log.Warn().Err(err).Str("id", req.Id).Msg("handler::UpdateWebhook - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.UpdateWebhook(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *webhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.DeleteWebhookRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	req.ShopId = c.Params("id")
+	req.Id = c.Params("webhook_id")
+	req.UserId = middleware.GetLocals(c).UserId
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteWebhook - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.DeleteWebhook(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
+
+func (h *webhookHandler) GetDeliveries(c *fiber.Ctx) error {
+	var (
+		req        = new(entity.DeliveriesRequest)
+		ctx        = c.Context()
+		validators = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetDeliveries - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ShopId = c.Params("id")
+	req.WebhookId = c.Params("webhook_id")
+	req.UserId = middleware.GetLocals(c).UserId
+	req.SetDefault()
+
+	if err := validators.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetDeliveries - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetDeliveries(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
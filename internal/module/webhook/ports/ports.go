@@ -0,0 +1,44 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/webhook/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/webhook"
+)
+
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, req *entity.CreateWebhookRequest) (*entity.CreateWebhookResponse, error)
+	GetWebhook(ctx context.Context, req *entity.GetWebhookRequest) (*entity.WebhookItem, error)
+	GetWebhooks(ctx context.Context, req *entity.WebhooksRequest) (*entity.WebhooksResponse, error)
+	UpdateWebhook(ctx context.Context, req *entity.UpdateWebhookRequest) (*entity.WebhookItem, error)
+	DeleteWebhook(ctx context.Context, req *entity.DeleteWebhookRequest) error
+	GetDeliveries(ctx context.Context, req *entity.DeliveriesRequest) (*entity.DeliveriesResponse, error)
+	DispatchEvents(ctx context.Context, limit int) (events int, deliveries int, err error)
+	LeaseDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.Delivery, error)
+	SaveDeliveryResult(ctx context.Context, result *entity.DeliveryResult, disableAfter int) error
+	DeleteFinishedDeliveries(ctx context.Context, before time.Time) (int, error)
+}
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, req *entity.CreateWebhookRequest) (*entity.CreateWebhookResponse, error)
+	GetWebhook(ctx context.Context, req *entity.GetWebhookRequest) (*entity.WebhookItem, error)
+	GetWebhooks(ctx context.Context, req *entity.WebhooksRequest) (*entity.WebhooksResponse, error)
+	UpdateWebhook(ctx context.Context, req *entity.UpdateWebhookRequest) (*entity.WebhookItem, error)
+	DeleteWebhook(ctx context.Context, req *entity.DeleteWebhookRequest) error
+	GetDeliveries(ctx context.Context, req *entity.DeliveriesRequest) (*entity.DeliveriesResponse, error)
+	Dispatch(ctx context.Context) (int, error)
+	Deliver(ctx context.Context) (succeeded int, failed int, err error)
+	PurgeDeliveries(ctx context.Context, before time.Time) (int, error)
+}
+
+// ShopRepository reads the owners of the shops, implemented by the shop repository.
+type ShopRepository interface {
+	GetShopOwner(ctx context.Context, shopId string) (string, error)
+}
+
+// WebhookSender posts the signed deliveries, implemented by the webhook package.
+type WebhookSender interface {
+	Send(ctx context.Context, req *webhook.Request) (int, error)
+}
@@ -0,0 +1,203 @@
+package repository
+
+const (
+	queryWebhookColumns = `
+			id,
+			shop_id,
+			url,
+			events,
+			enabled,
+			failure_count,
+			disabled_at,
+			created_at,
+			updated_at`
+
+	queryInsertWebhook = `
+		INSERT INTO webhooks (shop_id, url, events, secret)
+		VALUES (?, ?, ?, ?)
+		RETURNING` + queryWebhookColumns + `,
+			secret
+	`
+
+	queryGetWebhook = `
+		SELECT` + queryWebhookColumns + `
+		FROM webhooks
+		WHERE id = ? AND shop_id = ?
+	`
+
+	queryGetWebhooks = `
+		SELECT
+			COUNT(id) OVER() as total_data,` + queryWebhookColumns + `
+		FROM webhooks
+		WHERE shop_id = ?
+		ORDER BY created_at DESC, id
+		LIMIT ? OFFSET ?
+	`
+
+	// queryUpdateWebhook sets only the columns of the patch, %s is the list of "column = :column," assignments.
+	queryUpdateWebhook = `
+		UPDATE webhooks
+		SET
+			%s
+			updated_at = NOW()
+		WHERE id = :id AND shop_id = :shop_id
+		RETURNING` + queryWebhookColumns + `
+	`
+
+	queryDeleteWebhook = `
+		DELETE FROM webhooks
+		WHERE id = ? AND shop_id = ?
+	`
+
+	queryGetDeliveries = `
+		SELECT
+			COUNT(id) OVER() as total_data,
+			id,
+			event_id,
+			event_type,
+			payload,
+			status,
+			attempts,
+			CASE WHEN status = 'pending' THEN next_attempt_at END AS next_attempt_at,
+			response_status,
+			last_error,
+			created_at,
+			delivered_at
+		FROM webhook_deliveries
+		WHERE webhook_id = ?
+			AND (? = '' OR status = ?)
+		ORDER BY created_at DESC, id
+		LIMIT ? OFFSET ?
+	`
+
+	// queryDispatchEvents turns the next undispatched outbox events into a delivery per enabled webhook of
+	// their shop subscribed to them, directly or with a wildcard. The shop of a product or stock event is
+	// the shop_id of its payload. The body of a delivery is the envelope published by the outbox relay.
+	queryDispatchEvents = `
+		WITH events AS (
+			SELECT
+				id,
+				event_id,
+				aggregate_type,
+				aggregate_id,
+				event_type,
+				payload,
+				created_at
+			FROM outbox_events
+			WHERE dispatched_at IS NULL
+			ORDER BY id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		), deliveries AS (
+			INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload)
+			SELECT
+				w.id,
+				e.event_id,
+				e.event_type,
+				jsonb_build_object(
+					'id', e.event_id,
+					'type', e.event_type,
+					'aggregate_type', e.aggregate_type,
+					'aggregate_id', e.aggregate_id,
+					'occurred_at', e.created_at,
+					'data', e.payload
+				)
+			FROM events e
+			JOIN webhooks w ON w.shop_id = CASE
+				WHEN e.aggregate_type = CAST(? AS VARCHAR) THEN e.aggregate_id
+				ELSE CAST(e.payload->>'shop_id' AS UUID)
+			END
+			WHERE w.enabled
+				AND (
+					e.event_type = ANY(w.events)
+					OR split_part(e.event_type, '.', 1) || '.*' = ANY(w.events)
+					OR '*' = ANY(w.events)
+				)
+			ORDER BY e.id, w.id
+			ON CONFLICT (webhook_id, event_id) DO NOTHING
+			RETURNING id
+		), dispatched AS (
+			UPDATE outbox_events
+			SET
+				dispatched_at = NOW()
+			WHERE id IN (SELECT id FROM events)
+			RETURNING id
+		)
+		SELECT
+			(SELECT COUNT(*) FROM dispatched) AS events,
+			(SELECT COUNT(*) FROM deliveries) AS deliveries
+	`
+
+	// queryLeaseDeliveries pushes back the next attempt of the due deliveries of the enabled webhooks by the
+	// lease, so that another run does not send them while they are sent. A delivery whose result is not
+	// saved, ex: the process stopped, is sent again when the lease ends.
+	queryLeaseDeliveries = `
+		UPDATE webhook_deliveries d
+		SET
+			next_attempt_at = NOW() + CAST(? AS INTEGER) * INTERVAL '1 second'
+		FROM webhooks w
+		WHERE w.id = d.webhook_id
+			AND d.id IN (
+				SELECT dd.id
+				FROM webhook_deliveries dd
+				JOIN webhooks ww ON ww.id = dd.webhook_id
+				WHERE dd.status = 'pending' AND dd.next_attempt_at <= NOW() AND ww.enabled
+				ORDER BY dd.next_attempt_at
+				LIMIT ?
+				FOR UPDATE OF dd SKIP LOCKED
+			)
+		RETURNING
+			d.id,
+			d.webhook_id,
+			d.event_id,
+			d.event_type,
+			d.payload,
+			d.attempts,
+			w.url,
+			w.secret
+	`
+
+	queryDeliverySucceeded = `
+		UPDATE webhook_deliveries
+		SET
+			status = 'succeeded',
+			attempts = attempts + 1,
+			response_status = ?,
+			last_error = NULL,
+			delivered_at = NOW()
+		WHERE id = ?
+	`
+
+	queryDeliveryFailed = `
+		UPDATE webhook_deliveries
+		SET
+			status = ?,
+			attempts = attempts + 1,
+			response_status = ?,
+			last_error = ?,
+			next_attempt_at = ?
+		WHERE id = ?
+	`
+
+	queryResetWebhookFailures = `
+		UPDATE webhooks
+		SET
+			failure_count = 0
+		WHERE id = ? AND failure_count > 0
+	`
+
+	// queryAddWebhookFailure disables the webhook when its consecutive failures reach the limit.
+	queryAddWebhookFailure = `
+		UPDATE webhooks
+		SET
+			failure_count = failure_count + 1,
+			enabled = enabled AND failure_count + 1 < ?,
+			disabled_at = CASE WHEN enabled AND failure_count + 1 >= ? THEN NOW() ELSE disabled_at END
+		WHERE id = ?
+	`
+
+	queryDeleteFinishedDeliveries = `
+		DELETE FROM webhook_deliveries
+		WHERE status <> 'pending' AND created_at < ?
+	`
+)
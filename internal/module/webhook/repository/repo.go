@@ -0,0 +1,331 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	outbox "github.com/hilmiikhsan/shopeefun-product-service/internal/module/outbox/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/webhook/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/webhook/ports"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.WebhookRepository = &webhookRepository{}
+
+type webhookRepository struct {
+	db *sqlx.DB
+}
+
+func NewWebhookRepository(db *sqlx.DB) *webhookRepository {
+	return &webhookRepository{
+		db: db,
+	}
+}
+
+// webhookDao scans a webhook with its events.
+type webhookDao struct {
+	entity.WebhookItem
+	Events pq.StringArray `db:"events"`
+}
+
+func (d *webhookDao) item() entity.WebhookItem {
+	item := d.WebhookItem
+	item.Events = []string(d.Events)
+
+	return item
+}
+
+// withTx runs fn inside a transaction, rolling it back when fn returns an error.
+func (r *webhookRepository) withTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::withTx - Failed to begin transaction")
+		return err
+	}
+
+	if err = fn(tx); err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			log.Error().Err(errRollback).Msg("repository::withTx - Failed to rollback transaction")
+		}
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("repository::withTx - Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) CreateWebhook(ctx context.Context, req *entity.CreateWebhookRequest) (*entity.CreateWebhookResponse, error) {
+	var data struct {
+		webhookDao
+		Secret string `db:"secret"`
+	}
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(queryInsertWebhook),
+		req.ShopId,
+		req.Url,
+		pq.Array(req.Events),
+		req.Secret,
+	).StructScan(&data)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", req.ShopId).Str("url", req.Url).Msg("repository::CreateWebhook - Failed to create webhook")
+		return nil, err
+	}
+
+	return &entity.CreateWebhookResponse{
+		WebhookItem: data.item(),
+		Secret:      data.Secret,
+	}, nil
+}
+
+func (r *webhookRepository) GetWebhook(ctx context.Context, req *entity.GetWebhookRequest) (*entity.WebhookItem, error) {
+	var data webhookDao
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(queryGetWebhook), req.Id, req.ShopId).StructScan(&data)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetWebhook - Failed to get webhook")
+		return nil, err
+	}
+
+	resp := data.item()
+
+	return &resp, nil
+}
+
+func (r *webhookRepository) GetWebhooks(ctx context.Context, req *entity.WebhooksRequest) (*entity.WebhooksResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		webhookDao
+	}
+
+	var (
+		resp = new(entity.WebhooksResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.WebhookItem, 0, req.Paginate)
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(queryGetWebhooks),
+		req.ShopId,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetWebhooks - Failed to get webhooks")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.item())
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+// UpdateWebhook updates the members of the patch. Enabling the webhook resets its failures.
+func (r *webhookRepository) UpdateWebhook(ctx context.Context, req *entity.UpdateWebhookRequest) (*entity.WebhookItem, error) {
+	var (
+		data   webhookDao
+		sets   = make([]string, 0, len(entity.UpdateWebhookFields)+2)
+		params = map[string]any{"id": req.Id, "shop_id": req.ShopId}
+	)
+	set := func(column string, value any) {
+		sets = append(sets, column+" = :"+column+",")
+		params[column] = value
+	}
+
+	if req.Fields.Has("url") {
+		set("url", req.Url)
+	}
+
+	if req.Fields.Has("events") {
+		set("events", pq.Array(*req.Events))
+	}
+
+	if req.Fields.Has("secret") {
+		set("secret", req.Secret)
+	}
+
+	if req.Fields.Has("enabled") {
+		set("enabled", req.Enabled)
+		if *req.Enabled {
+			set("failure_count", 0)
+			set("disabled_at", nil)
+		}
+	}
+
+	query, args, err := sqlx.Named(fmt.Sprintf(queryUpdateWebhook, strings.Join(sets, "\n\t\t\t")), params)
+	if err != nil {
+		log.Error().Err(err).Str("id", req.Id).Msg("repository::UpdateWebhook - Failed to build query")
+		return nil, err
+	}
+
+	err = r.db.QueryRowxContext(ctx, r.db.Rebind(query), args...).StructScan(&data)
+	if err != nil {
+		log.Error().Err(err).Str("id", req.Id).Msg("repository::UpdateWebhook - Failed to update webhook")
+		return nil, err
+	}
+
+	resp := data.item()
+
+	return &resp, nil
+}
+
+// DeleteWebhook deletes a webhook with its delivery log.
+func (r *webhookRepository) DeleteWebhook(ctx context.Context, req *entity.DeleteWebhookRequest) error {
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(queryDeleteWebhook), req.Id, req.ShopId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteWebhook - Failed to delete webhook")
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteWebhook - Failed to get affected rows")
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) GetDeliveries(ctx context.Context, req *entity.DeliveriesRequest) (*entity.DeliveriesResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.DeliveryItem
+	}
+
+	var (
+		resp = new(entity.DeliveriesResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.DeliveryItem, 0, req.Paginate)
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(queryGetDeliveries),
+		req.WebhookId,
+		req.Status, req.Status,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetDeliveries - Failed to get deliveries")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.DeliveryItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+// DispatchEvents turns at most limit undispatched outbox events into deliveries and returns how many
+// events were read and how many deliveries were created. Concurrent runs read different events.
+func (r *webhookRepository) DispatchEvents(ctx context.Context, limit int) (int, int, error) {
+	var events, deliveries int
+
+	err := r.db.QueryRowContext(ctx, r.db.Rebind(queryDispatchEvents), limit, outbox.AggregateShop).Scan(&events, &deliveries)
+	if err != nil {
+		log.Error().Err(err).Int("limit", limit).Msg("repository::DispatchEvents - Failed to dispatch events")
+		return 0, 0, err
+	}
+
+	return events, deliveries, nil
+}
+
+// LeaseDeliveries returns at most limit due deliveries and pushes back their next attempt by the lease.
+func (r *webhookRepository) LeaseDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.Delivery, error) {
+	var deliveries []entity.Delivery
+
+	err := r.db.SelectContext(ctx, &deliveries, r.db.Rebind(queryLeaseDeliveries), int(lease.Seconds()), limit)
+	if err != nil {
+		log.Error().Err(err).Int("limit", limit).Msg("repository::LeaseDeliveries - Failed to lease deliveries")
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// SaveDeliveryResult records an attempt of a delivery. A success resets the failures of the webhook,
+// a failure adds one and disables the webhook when it reaches disableAfter.
+func (r *webhookRepository) SaveDeliveryResult(ctx context.Context, result *entity.DeliveryResult, disableAfter int) error {
+	var responseStatus *int
+	if result.ResponseStatus != 0 {
+		responseStatus = &result.ResponseStatus
+	}
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		if result.Error == "" {
+			_, err := tx.ExecContext(ctx, tx.Rebind(queryDeliverySucceeded), responseStatus, result.Id)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.ExecContext(ctx, tx.Rebind(queryResetWebhookFailures), result.WebhookId)
+			return err
+		}
+
+		status := entity.DeliveryPending
+		if result.Failed {
+			status = entity.DeliveryFailed
+		}
+
+		_, err := tx.ExecContext(ctx, tx.Rebind(queryDeliveryFailed),
+			status,
+			responseStatus,
+			result.Error,
+			result.NextAttemptAt,
+			result.Id,
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, tx.Rebind(queryAddWebhookFailure), disableAfter, disableAfter, result.WebhookId)
+		return err
+	})
+	if err != nil {
+		log.Error().Err(err).Any("payload", result).Msg("repository::SaveDeliveryResult - Failed to save delivery result")
+		return err
+	}
+
+	return nil
+}
+
+// DeleteFinishedDeliveries deletes the succeeded and failed deliveries created before the given time.
+func (r *webhookRepository) DeleteFinishedDeliveries(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(queryDeleteFinishedDeliveries), before)
+	if err != nil {
+		log.Error().Err(err).Time("before", before).Msg("repository::DeleteFinishedDeliveries - Failed to delete finished deliveries")
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	shopService "github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/service"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/webhook/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/webhook/ports"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+)
+
+var _ ports.WebhookService = &webhookService{}
+
+type webhookService struct {
+	repo         ports.WebhookRepository
+	shopRepo     ports.ShopRepository
+	sender       ports.WebhookSender
+	maxAttempts  int
+	disableAfter int
+}
+
+func NewWebhookService(repo ports.WebhookRepository, shopRepo ports.ShopRepository, sender ports.WebhookSender, maxAttempts, disableAfter int) *webhookService {
+	return &webhookService{
+		repo:         repo,
+		shopRepo:     shopRepo,
+		sender:       sender,
+		maxAttempts:  maxAttempts,
+		disableAfter: disableAfter,
+	}
+}
+
+func (s *webhookService) CreateWebhook(ctx context.Context, req *entity.CreateWebhookRequest) (*entity.CreateWebhookResponse, error) {
+	if err := shopService.CheckShopOwner(ctx, s.shopRepo, req.ShopId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	if req.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			log.Error().Err(err).Str("shop_id", req.ShopId).Msg("service::CreateWebhook - Failed to generate secret")
+			return nil, err
+		}
+		req.Secret = secret
+	}
+
+	return s.repo.CreateWebhook(ctx, req)
+}
+
+func (s *webhookService) GetWebhook(ctx context.Context, req *entity.GetWebhookRequest) (*entity.WebhookItem, error) {
+	if err := shopService.CheckShopOwner(ctx, s.shopRepo, req.ShopId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.GetWebhook(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Webhook not found"))
+	}
+
+	return resp, err
+}
+
+func (s *webhookService) GetWebhooks(ctx context.Context, req *entity.WebhooksRequest) (*entity.WebhooksResponse, error) {
+	if err := shopService.CheckShopOwner(ctx, s.shopRepo, req.ShopId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetWebhooks(ctx, req)
+}
+
+func (s *webhookService) UpdateWebhook(ctx context.Context, req *entity.UpdateWebhookRequest) (*entity.WebhookItem, error) {
+	if err := req.Fields.Check(entity.UpdateWebhookFields); err != nil {
+		return nil, err
+	}
+
+	if err := shopService.CheckShopOwner(ctx, s.shopRepo, req.ShopId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.UpdateWebhook(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Webhook not found"))
+	}
+
+	return resp, err
+}
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, req *entity.DeleteWebhookRequest) error {
+	if err := shopService.CheckShopOwner(ctx, s.shopRepo, req.ShopId, req.UserId); err != nil {
+		return err
+	}
+
+	err := s.repo.DeleteWebhook(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(fiber.StatusNotFound, errmsg.WithMessage("Webhook not found"))
+	}
+
+	return err
+}
+
+// GetDeliveries lists the delivery log of a webhook, the latest deliveries first.
+func (s *webhookService) GetDeliveries(ctx context.Context, req *entity.DeliveriesRequest) (*entity.DeliveriesResponse, error) {
+	_, err := s.GetWebhook(ctx, &entity.GetWebhookRequest{ShopId: req.ShopId, UserId: req.UserId, Id: req.WebhookId})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetDeliveries(ctx, req)
+}
+
+// generateSecret returns a random secret of 32 bytes, ex: whsec_3f0a...
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// PurgeDeliveries deletes the finished deliveries created before the given time.
+func (s *webhookService) PurgeDeliveries(ctx context.Context, before time.Time) (int, error) {
+	return s.repo.DeleteFinishedDeliveries(ctx, before)
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/internal/module/webhook/entity"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/webhook"
+)
+
+const (
+	// dispatchBatchSize is how many outbox events are turned into deliveries per statement.
+	dispatchBatchSize = 100
+
+	// deliverBatchSize is how many deliveries are sent per run, by deliverWorkers at a time.
+	deliverBatchSize = 50
+	deliverWorkers   = 10
+
+	// deliveryLease is how long a sent delivery is hidden from the other runs, it must outlast a run.
+	deliveryLease = 5 * time.Minute
+)
+
+// Dispatch turns the undispatched outbox events into deliveries batch by batch and returns how many
+// deliveries were created.
+func (s *webhookService) Dispatch(ctx context.Context) (int, error) {
+	var total int
+
+	for {
+		events, deliveries, err := s.repo.DispatchEvents(ctx, dispatchBatchSize)
+		total += deliveries
+		if err != nil {
+			return total, err
+		}
+
+		if events < dispatchBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// Deliver sends the due deliveries and returns how many succeeded and failed. The deliveries are sent
+// concurrently, a receiver must not expect them in the order of the events and should drop an event
+// older than the last one it handled for the same aggregate.
+func (s *webhookService) Deliver(ctx context.Context) (int, int, error) {
+	deliveries, err := s.repo.LeaseDeliveries(ctx, deliverBatchSize, deliveryLease)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var (
+		mu                sync.Mutex
+		wg                sync.WaitGroup
+		succeeded, failed int
+		sem               = make(chan struct{}, deliverWorkers)
+	)
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		sem <- struct{}{}
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			ok := s.deliver(ctx, delivery)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if ok {
+				succeeded++
+			} else {
+				failed++
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return succeeded, failed, nil
+}
+
+// deliver sends a delivery and saves the result of the attempt, it returns whether the delivery succeeded.
+// A result that can not be saved is retried when the lease of the delivery ends.
+func (s *webhookService) deliver(ctx context.Context, delivery *entity.Delivery) bool {
+	status, errSend := s.sender.Send(ctx, &webhook.Request{
+		Url:        delivery.Url,
+		Secret:     delivery.Secret,
+		Event:      delivery.EventType,
+		DeliveryId: delivery.Id,
+		Body:       delivery.Payload,
+	})
+
+	result := &entity.DeliveryResult{
+		Id:             delivery.Id,
+		WebhookId:      delivery.WebhookId,
+		ResponseStatus: status,
+	}
+
+	if errSend != nil {
+		attempts := delivery.Attempts + 1
+
+		result.Error = errSend.Error()
+		result.Failed = attempts >= s.maxAttempts
+		result.NextAttemptAt = time.Now().Add(entity.NextAttemptDelay(attempts))
+
+		log.Warn().Err(errSend).Str("id", delivery.Id).Str("webhook_id", delivery.WebhookId).Int("attempts", attempts).
+			Msg("service::deliver - Failed to deliver webhook")
+	}
+
+	if err := s.repo.SaveDeliveryResult(ctx, result, s.disableAfter); err != nil {
+		return false
+	}
+
+	return errSend == nil
+}
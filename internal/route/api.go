@@ -2,8 +2,13 @@ package route
 
 import (
 	"github.com/gofiber/fiber/v2"
+	handlerBrand "github.com/hilmiikhsan/shopeefun-product-service/internal/module/brand/handler/rest"
+	handlerCategory "github.com/hilmiikhsan/shopeefun-product-service/internal/module/category/handler/rest"
 	handlerProduct "github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/handler/rest"
 	handlerShop "github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/handler/rest"
+	handlerStorage "github.com/hilmiikhsan/shopeefun-product-service/internal/module/storage/handler/rest"
+	handlerVoucher "github.com/hilmiikhsan/shopeefun-product-service/internal/module/voucher/handler/rest"
+	handlerWebhook "github.com/hilmiikhsan/shopeefun-product-service/internal/module/webhook/handler/rest"
 	"github.com/hilmiikhsan/shopeefun-product-service/pkg/response"
 	"github.com/rs/zerolog/log"
 )
@@ -15,6 +20,11 @@ func SetupRoutes(app *fiber.App) {
 
 	handlerShop.NewShopHandler().Register(api)
 	handlerProduct.NewProductHandler().Register(api)
+	handlerCategory.NewCategoryHandler().Register(api)
+	handlerBrand.NewBrandHandler().Register(api)
+	handlerVoucher.NewVoucherHandler().Register(api)
+	handlerWebhook.NewWebhookHandler().Register(api)
+	handlerStorage.NewStorageHandler().Register(app.Group("/api"))
 
 	// fallback route
 	app.Use(func(c *fiber.Ctx) error {
@@ -0,0 +1,17 @@
+package route
+
+import (
+	jobOutbox "github.com/hilmiikhsan/shopeefun-product-service/internal/module/outbox/handler/job"
+	jobProduct "github.com/hilmiikhsan/shopeefun-product-service/internal/module/product/handler/job"
+	jobShop "github.com/hilmiikhsan/shopeefun-product-service/internal/module/shop/handler/job"
+	jobWebhook "github.com/hilmiikhsan/shopeefun-product-service/internal/module/webhook/handler/job"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/scheduler"
+)
+
+// SetupJobs schedules the background jobs of every module.
+func SetupJobs(s *scheduler.Scheduler) {
+	jobProduct.NewProductJob().Register(s)
+	jobShop.NewShopJob().Register(s)
+	jobOutbox.NewOutboxJob().Register(s)
+	jobWebhook.NewWebhookJob().Register(s)
+}
@@ -171,6 +171,15 @@ func errorValidationHandler[T any](err error, payload *T) (int, map[string][]str
 			oneOfValues[len(oneOfValues)-1] = "atau " + oneOfValues[len(oneOfValues)-1]
 			oneOfValuesStr := strings.Join(oneOfValues, ", ")
 			message = fmt.Sprintf("%s harus salah satu dari %s.", fieldInMsg, oneOfValuesStr)
+		case "oneof_csv":
+			// message = fmt.Sprintf("%s must be a comma separated list of %s.", fieldInMsg, err.Param())
+			oneOfValues := strings.Split(err.Param(), " ")
+			oneOfValues[len(oneOfValues)-1] = "atau " + oneOfValues[len(oneOfValues)-1]
+			oneOfValuesStr := strings.Join(oneOfValues, ", ")
+			message = fmt.Sprintf("%s harus berisi %s, dipisahkan dengan koma.", fieldInMsg, oneOfValuesStr)
+		case "slug":
+			// message = fmt.Sprintf("%s must only contain lowercase letters, numbers and dashes.", fieldInMsg)
+			message = fmt.Sprintf("%s hanya boleh berisi huruf kecil, angka, dan tanda hubung.", fieldInMsg)
 		case "unique_in_slice":
 			// message = fmt.Sprintf("%s elements must be unique.", fieldInMsg)
 			message = fmt.Sprintf("elemen %s harus unik.", fieldInMsg)
@@ -0,0 +1,41 @@
+package etag
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Format returns the ETag of a row version, ex: 3 => "3".
+func Format(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// Match tells whether an If-Match header matches a row version. An empty header or "*" matches every
+// version, otherwise the header is a comma separated list of ETags. Weak ETags are compared by value.
+func Match(header string, version int) bool {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return true
+	}
+
+	current := Format(version)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == current {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StaleError is returned when an update was made against a stale version,
+// Current holds the current state of the resource and Version its version.
+type StaleError struct {
+	Version int
+	Current any
+}
+
+func (e *StaleError) Error() string {
+	return "resource was modified, its current version is " + Format(e.Version)
+}
@@ -0,0 +1,33 @@
+package etag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, `"1"`, Format(1))
+	assert.Equal(t, `"42"`, Format(42))
+}
+
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		header  string
+		version int
+		match   bool
+	}{
+		{"", 3, true},
+		{"*", 3, true},
+		{`"3"`, 3, true},
+		{`W/"3"`, 3, true},
+		{`"1", "3"`, 3, true},
+		{`"2"`, 3, false},
+		{`3`, 3, false},
+		{`"30"`, 3, false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.match, Match(c.header, c.version), c.header)
+	}
+}
@@ -0,0 +1,69 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+)
+
+var ErrNotObject = errors.New("merge patch must be a JSON object")
+
+// Fields are the members of a JSON merge patch document (RFC 7396), mapped to whether they are
+// set to null. A member that is absent is left unchanged and a null member removes the value.
+type Fields map[string]bool
+
+// Parse reads the members of a merge patch body, ex: {"stock":0,"brand_id":null} => {stock:false brand_id:true}.
+func Parse(body []byte) (Fields, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(body, &members); err != nil {
+		return nil, err
+	}
+
+	// "null" decodes into a nil map, a patch replacing the whole resource is not supported
+	if members == nil {
+		return nil, ErrNotObject
+	}
+
+	fields := make(Fields, len(members))
+	for name, value := range members {
+		fields[name] = bytes.Equal(bytes.TrimSpace(value), []byte("null"))
+	}
+
+	return fields, nil
+}
+
+// Has tells whether a member is in the patch, null or not.
+func (f Fields) Has(name string) bool {
+	_, ok := f[name]
+	return ok
+}
+
+// IsNull tells whether a member is in the patch and set to null.
+func (f Fields) IsNull(name string) bool {
+	return f[name]
+}
+
+// Check returns a bad request error when none of the members is in the patch
+// or when a member that is not nullable is set to null.
+func (f Fields) Check(members []string, nullable ...string) error {
+	if !slices.ContainsFunc(members, f.Has) {
+		return errmsg.NewCustomErrors(http.StatusBadRequest, errmsg.WithMessage("Nothing to update"))
+	}
+
+	errs := errmsg.NewCustomErrors(http.StatusBadRequest, errmsg.WithMessage("Invalid patch"))
+	for _, name := range members {
+		if f.IsNull(name) && !slices.Contains(nullable, name) {
+			errs.Add(name, name+" can not be null.")
+		}
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+)
+
+func TestParse(t *testing.T) {
+	fields, err := Parse([]byte(`{"stock": 0, "brand_id": null, "name": "Shirt"}`))
+	require.NoError(t, err)
+
+	assert.True(t, fields.Has("stock"))
+	assert.False(t, fields.IsNull("stock"))
+	assert.True(t, fields.Has("brand_id"))
+	assert.True(t, fields.IsNull("brand_id"))
+	assert.False(t, fields.Has("price"))
+	assert.False(t, fields.IsNull("price"))
+
+	_, err = Parse([]byte(`null`))
+	assert.ErrorIs(t, err, ErrNotObject)
+
+	_, err = Parse([]byte(`[1, 2]`))
+	assert.Error(t, err)
+}
+
+func TestCheck(t *testing.T) {
+	members := []string{"name", "brand_id", "stock"}
+
+	fields, _ := Parse([]byte(`{"stock": 0, "brand_id": null}`))
+	assert.NoError(t, fields.Check(members, "brand_id"))
+
+	fields, _ = Parse([]byte(`{"unknown": 1}`))
+	err := fields.Check(members, "brand_id")
+	require.Error(t, err)
+	assert.Equal(t, "Nothing to update", err.Error())
+
+	fields, _ = Parse([]byte(`{"name": null, "brand_id": null}`))
+	err = fields.Check(members, "brand_id")
+
+	var customErr *errmsg.CustomError
+	require.ErrorAs(t, err, &customErr)
+	assert.Equal(t, 400, customErr.Code)
+	assert.Contains(t, customErr.Errors, "name")
+	assert.NotContains(t, customErr.Errors, "brand_id")
+}
@@ -0,0 +1,29 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// LogPublisher writes the messages to the log, for the environments without a broker.
+type LogPublisher struct{}
+
+func NewLogPublisher() *LogPublisher {
+	return &LogPublisher{}
+}
+
+func (p *LogPublisher) Publish(ctx context.Context, msg *Message) error {
+	log.Info().
+		Str("id", msg.Id).
+		Str("subject", msg.Subject).
+		Str("key", msg.Key).
+		RawJSON("payload", msg.Payload).
+		Msg("publisher::Publish - Message published")
+
+	return nil
+}
+
+func (p *LogPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,38 @@
+package publisher
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// MemoryPublisher keeps the messages in memory, for the tests and the local development.
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(ctx context.Context, msg *Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = append(p.messages, *msg)
+
+	return nil
+}
+
+// Messages returns the published messages in publication order.
+func (p *MemoryPublisher) Messages() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return slices.Clone(p.messages)
+}
+
+func (p *MemoryPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,148 @@
+package publisher
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type published struct {
+	subject string
+	header  string
+	payload string
+}
+
+// fakeNats accepts one connection and answers every HPUB with respond.
+func fakeNats(t *testing.T, respond func(reply string) string) (string, <-chan published) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	messages := make(chan published, 10)
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		io.WriteString(conn, "INFO {\"server_id\":\"fake\",\"headers\":true,\"proto\":1,\"max_payload\":1048576}\r\n")
+
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+
+			op, args, _ := strings.Cut(line, " ")
+			// CONNECT and the SUB of the reply inbox are ignored, the inbox is the first subscription, sid 1
+			switch op {
+			case "PING":
+				io.WriteString(conn, "PONG\r\n")
+			case "HPUB":
+				fields := strings.Fields(args)
+				headerSize, _ := strconv.Atoi(fields[2])
+				total, _ := strconv.Atoi(fields[3])
+
+				data := make([]byte, total+2)
+				if _, err := io.ReadFull(r, data); err != nil {
+					return
+				}
+
+				messages <- published{fields[0], string(data[:headerSize]), string(data[headerSize:total])}
+				io.WriteString(conn, respond(fields[1]))
+			}
+		}
+	}()
+
+	return ln.Addr().String(), messages
+}
+
+func ack(payload string) func(reply string) string {
+	return func(reply string) string {
+		return fmt.Sprintf("PING\r\nMSG %s 1 %d\r\n%s\r\n", reply, len(payload), payload)
+	}
+}
+
+func TestNatsPublish(t *testing.T) {
+	addr, messages := fakeNats(t, ack(`{"stream":"CATALOG","seq":1}`))
+
+	p, err := NewNatsPublisher(addr, "test", time.Second)
+	require.NoError(t, err)
+	defer p.Close()
+
+	for _, id := range []string{"e1", "e2"} {
+		err = p.Publish(context.Background(), &Message{Id: id, Subject: "shopeefun.product.created", Key: "p1", Payload: []byte(`{"id":"p1"}`)})
+		require.NoError(t, err)
+
+		msg := <-messages
+		assert.Equal(t, "shopeefun.product.created", msg.subject)
+		assert.Contains(t, msg.header, "Nats-Msg-Id: "+id+"\r\n")
+		assert.Contains(t, msg.header, "Key: p1\r\n")
+		assert.Equal(t, `{"id":"p1"}`, msg.payload)
+	}
+}
+
+func TestNatsPublishRejected(t *testing.T) {
+	addr, _ := fakeNats(t, ack(`{"error":{"code":503,"description":"insufficient resources"}}`))
+
+	p, err := NewNatsPublisher("nats://"+addr, "test", time.Second)
+	require.NoError(t, err)
+	defer p.Close()
+
+	err = p.Publish(context.Background(), &Message{Id: "e1", Subject: "shopeefun.shop.updated", Key: "s1", Payload: []byte(`{}`)})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrUnavailable)
+	assert.Contains(t, err.Error(), "insufficient resources")
+}
+
+func TestNatsPublishNoStream(t *testing.T) {
+	addr, _ := fakeNats(t, func(reply string) string {
+		header := "NATS/1.0 503\r\n\r\n"
+		return fmt.Sprintf("HMSG %s 1 %d %d\r\n%s\r\n", reply, len(header), len(header), header)
+	})
+
+	p, err := NewNatsPublisher(addr, "test", time.Second)
+	require.NoError(t, err)
+	defer p.Close()
+
+	err = p.Publish(context.Background(), &Message{Id: "e1", Subject: "unbound", Key: "k", Payload: []byte(`{}`)})
+	assert.EqualError(t, err, "nats: no stream is bound to the subject")
+}
+
+func TestNatsPublishTimeout(t *testing.T) {
+	addr, _ := fakeNats(t, func(reply string) string { return "" })
+
+	p, err := NewNatsPublisher(addr, "test", 100*time.Millisecond)
+	require.NoError(t, err)
+	defer p.Close()
+
+	err = p.Publish(context.Background(), &Message{Id: "e1", Subject: "s", Key: "k", Payload: []byte(`{}`)})
+	assert.ErrorIs(t, err, ErrUnavailable)
+}
+
+func TestNatsPublishUnavailable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	ln.Close()
+
+	p, err := NewNatsPublisher(addr, "test", time.Second)
+	require.NoError(t, err)
+	defer p.Close()
+
+	err = p.Publish(context.Background(), &Message{Id: "e1", Subject: "s", Key: "k", Payload: []byte(`{}`)})
+	assert.ErrorIs(t, err, ErrUnavailable)
+}
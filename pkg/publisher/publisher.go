@@ -0,0 +1,24 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrUnavailable is returned when the broker can not be reached, the messages after it would fail the same way.
+var ErrUnavailable = errors.New("publisher is unavailable")
+
+// Message is an event published to a subject of the broker.
+type Message struct {
+	Id      string // unique id of the event, lets the broker drop the duplicates of a redelivery
+	Subject string // ex: "shopeefun.product.updated"
+	Key     string // ordering key, the messages of a key are published in order, ex: the product id
+	Payload []byte
+}
+
+// Publisher publishes messages to a broker. Publish returns once the broker acknowledged the message,
+// a message is never lost but it can be published twice when the acknowledgement is lost.
+type Publisher interface {
+	Publish(ctx context.Context, msg *Message) error
+	Close() error
+}
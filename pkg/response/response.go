@@ -1,6 +1,9 @@
 package response
 
-import "github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+import (
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/errmsg"
+	"github.com/hilmiikhsan/shopeefun-product-service/pkg/etag"
+)
 
 type Response map[string]any
 
@@ -57,3 +60,13 @@ func Error(errorMsg any) Response {
 		"message": "Permintaan anda gagal diproses",
 	}
 }
+
+// Stale answers an update made against an old version with the current state of the resource.
+func Stale(err *etag.StaleError) Response {
+	return Response{
+		"errors":  make(map[string][]string),
+		"success": false,
+		"message": "The resource was modified, retry with its current version",
+		"data":    err.Current,
+	}
+}
@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Scheduler runs background jobs at a fixed interval until it is stopped.
+type Scheduler struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New() *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Scheduler{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Every runs fn every interval, the first run starts after one interval.
+// A failing run is logged and retried at the next tick.
+func (s *Scheduler) Every(name string, interval time.Duration, fn func(ctx context.Context) error) {
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.C:
+				if err := fn(s.ctx); err != nil {
+					log.Error().Err(err).Str("job", name).Msg("scheduler::Every - Job failed")
+				}
+			}
+		}
+	}()
+
+	log.Info().Str("job", name).Dur("interval", interval).Msg("scheduler::Every - Job scheduled")
+}
+
+// Stop cancels the context of the running jobs and waits for them to return.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
@@ -0,0 +1,29 @@
+package slug
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Make turns a name into a lowercase, dash separated slug, ex: "Men's T-Shirts" => "men-s-t-shirts".
+func Make(name string) string {
+	var (
+		b    strings.Builder
+		dash = false
+	)
+
+	for _, r := range strings.ToLower(name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+
+		dash = true
+	}
+
+	return b.String()
+}
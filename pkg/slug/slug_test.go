@@ -0,0 +1,23 @@
+package slug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake(t *testing.T) {
+	cases := map[string]string{
+		"Electronics":       "electronics",
+		"Men's T-Shirts":    "men-s-t-shirts",
+		"  Home & Living  ": "home-living",
+		"H&M":               "h-m",
+		"3M":                "3m",
+		"Café":              "caf",
+		"---":               "",
+	}
+
+	for name, expected := range cases {
+		assert.Equal(t, expected, Make(name), name)
+	}
+}
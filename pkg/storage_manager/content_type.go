@@ -0,0 +1,26 @@
+package storage_manager
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// DetectContentType sniffs the content type of an uploaded file from its first 512 bytes,
+// the content type sent by the client is not trusted.
+func DetectContentType(fh *multipart.FileHeader) (string, error) {
+	file, err := fh.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+
+	return http.DetectContentType(head[:n]), nil
+}
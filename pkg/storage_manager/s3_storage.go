@@ -0,0 +1,105 @@
+package storage_manager
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrStorageNotConfigured = errors.New("storage is not configured")
+	ErrObjectNotFound       = errors.New("object not found")
+)
+
+// Objects are kept under a public or private prefix so that the public route can never serve private files.
+const (
+	PublicPrefix  = "public/"
+	PrivatePrefix = "private/"
+)
+
+// ObjectKey returns the bucket key of a file, ex: "private/products/{id}/{file}.png".
+func ObjectKey(filename string, private bool) string {
+	if private {
+		return PrivatePrefix + filename
+	}
+
+	return PublicPrefix + filename
+}
+
+// S3Storage stores objects in a single bucket of an S3 compatible storage.
+type S3Storage struct {
+	client *s3.Client
+	bucket string
+}
+
+func NewS3Storage(client *s3.Client, bucket string) *S3Storage {
+	return &S3Storage{
+		client: client,
+		bucket: bucket,
+	}
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if s.client == nil {
+		return ErrStorageNotConfigured
+	}
+
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(key),
+		Body:          body,
+		ContentLength: aws.Int64(size),
+		ContentType:   aws.String(contentType),
+	})
+	if err != nil {
+		log.Error().Err(err).Str("key", key).Msg("storage_manager::Put - Failed to put object")
+		return err
+	}
+
+	return nil
+}
+
+// Get returns the object body and content type, the caller must close the body.
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	if s.client == nil {
+		return nil, "", ErrStorageNotConfigured
+	}
+
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var errNoSuchKey *types.NoSuchKey
+		if errors.As(err, &errNoSuchKey) {
+			return nil, "", ErrObjectNotFound
+		}
+
+		log.Error().Err(err).Str("key", key).Msg("storage_manager::Get - Failed to get object")
+		return nil, "", err
+	}
+
+	return out.Body, aws.ToString(out.ContentType), nil
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	if s.client == nil {
+		return ErrStorageNotConfigured
+	}
+
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		log.Error().Err(err).Str("key", key).Msg("storage_manager::Delete - Failed to delete object")
+		return err
+	}
+
+	return nil
+}
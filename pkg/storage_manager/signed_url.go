@@ -15,16 +15,10 @@ import (
 func GenerateSignedURL(filename string, expiration time.Duration) string {
 	urlToSigned := config.Envs.App.BaseURL + "/api/storage/private/" + filename
 	var (
-		key            = []byte(config.Envs.Guard.JwtPrivateKey)
 		expirationTime = time.Now().UTC().Add(expiration).Unix()
-		data           = fmt.Sprintf("%s%d", urlToSigned, expirationTime)
+		signature      = sign(urlToSigned, expirationTime)
 	)
 
-	// Create a new HMAC by defining the hash type and the key (as byte array)
-	h := hmac.New(sha256.New, key)
-	h.Write([]byte(data))
-	signature := hex.EncodeToString(h.Sum(nil))
-
 	// Add the expiration time and signature to the URL
 	u, _ := url.Parse(urlToSigned)
 	q := u.Query()
@@ -34,3 +28,40 @@ func GenerateSignedURL(filename string, expiration time.Duration) string {
 
 	return u.String()
 }
+
+// VerifySignedURL checks the expires and signature query params of a URL made by GenerateSignedURL.
+func VerifySignedURL(filename, expires, signature string) bool {
+	expirationTime, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	if time.Now().UTC().Unix() > expirationTime {
+		return false
+	}
+
+	var (
+		urlToSigned = config.Envs.App.BaseURL + "/api/storage/private/" + filename
+		expected    = sign(urlToSigned, expirationTime)
+	)
+
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// GeneratePublicURL returns the URL of a file that can be served without signature.
+func GeneratePublicURL(filename string) string {
+	return config.Envs.App.BaseURL + "/api/storage/public/" + filename
+}
+
+func sign(urlToSigned string, expirationTime int64) string {
+	var (
+		key  = []byte(config.Envs.Guard.JwtPrivateKey)
+		data = fmt.Sprintf("%s%d", urlToSigned, expirationTime)
+	)
+
+	// Create a new HMAC by defining the hash type and the key (as byte array)
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// CursorKind is the type of the sort column, the value of a cursor must parse as it.
+type CursorKind int
+
+const (
+	CursorText   CursorKind = iota
+	CursorNumber            // ex: "129.90"
+	CursorTime              // RFC 3339, ex: "2024-08-27T12:28:07.123456Z"
+)
+
+// Cursor points at the row a keyset page starts after, it is sent to clients as an opaque token.
+type Cursor struct {
+	Sort     string `json:"s"`           // sort order the cursor was made for
+	Value    string `json:"v"`           // value of the sort column of the row
+	Id       string `json:"i"`           // id of the row, the tiebreaker of every sort order
+	Backward bool   `json:"b,omitempty"` // true when the page goes before the row (prev_cursor)
+}
+
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor decodes a token made for the given sort order. The id must be a UUID and the value
+// must parse as the kind of the sort column, so that a tampered token never reaches the database.
+func DecodeCursor(token, sort string, kind CursorKind) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if c.Sort != sort {
+		return nil, ErrInvalidCursor
+	}
+
+	if _, err := uuid.Parse(c.Id); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if !validCursorValue(c.Value, kind) {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+func validCursorValue(value string, kind CursorKind) bool {
+	switch kind {
+	case CursorNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		return err == nil && !math.IsNaN(n) && !math.IsInf(n, 0)
+	case CursorTime:
+		_, err := time.Parse(time.RFC3339Nano, value)
+		return err == nil
+	default:
+		// postgres refuses invalid UTF-8 and NUL in text
+		return utf8.ValidString(value) && !strings.ContainsRune(value, 0)
+	}
+}
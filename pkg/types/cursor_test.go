@@ -0,0 +1,63 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorEncodeDecode(t *testing.T) {
+	c := Cursor{
+		Sort:     "newest",
+		Value:    "2024-08-27T12:28:07.123456Z",
+		Id:       "0b6f4c1e-6a0e-4a51-9d6f-6b0d0f6f3c2a",
+		Backward: true,
+	}
+
+	decoded, err := DecodeCursor(c.Encode(), "newest", CursorTime)
+
+	assert.NoError(t, err)
+	assert.Equal(t, c, *decoded)
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	tokens := []string{
+		"not base64!",
+		"bm90IGpzb24",         // "not json"
+		"eyJzIjoibmV3ZXN0In0", // {"s":"newest"} without id
+	}
+
+	for _, token := range tokens {
+		_, err := DecodeCursor(token, "newest", CursorTime)
+		assert.ErrorIs(t, err, ErrInvalidCursor, token)
+	}
+}
+
+func TestDecodeCursorTampered(t *testing.T) {
+	const id = "0b6f4c1e-6a0e-4a51-9d6f-6b0d0f6f3c2a"
+
+	cases := []struct {
+		cursor Cursor
+		sort   string
+		kind   CursorKind
+		valid  bool
+	}{
+		{Cursor{Sort: "price_asc", Value: "129.90", Id: id}, "price_asc", CursorNumber, true},
+		{Cursor{Sort: "price_asc", Value: "129.90", Id: id}, "newest", CursorTime, false}, // another sort order
+		{Cursor{Sort: "price_asc", Value: "129.90", Id: "1 OR 1=1"}, "price_asc", CursorNumber, false},
+		{Cursor{Sort: "price_asc", Value: "cheap", Id: id}, "price_asc", CursorNumber, false},
+		{Cursor{Sort: "price_asc", Value: "NaN", Id: id}, "price_asc", CursorNumber, false},
+		{Cursor{Sort: "newest", Value: "yesterday", Id: id}, "newest", CursorTime, false},
+		{Cursor{Sort: "name", Value: "Kemeja", Id: id}, "name", CursorText, true},
+		{Cursor{Sort: "name", Value: "Kemeja\x00", Id: id}, "name", CursorText, false},
+	}
+
+	for _, tc := range cases {
+		_, err := DecodeCursor(tc.cursor.Encode(), tc.sort, tc.kind)
+		if tc.valid {
+			assert.NoError(t, err, tc.cursor)
+		} else {
+			assert.ErrorIs(t, err, ErrInvalidCursor, tc.cursor)
+		}
+	}
+}
@@ -5,6 +5,10 @@ type Meta struct {
 	Paginate  int `json:"paginate"`
 	TotalData int `json:"total_data"`
 	TotalPage int `json:"total_page"`
+
+	// keyset pagination tokens, only set by listings that support cursors
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 func (r *Meta) CountTotalPage(page, paginate, totalData int) {
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"reflect"
+	"regexp"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
@@ -59,6 +60,12 @@ func NewValidator() *Validator {
 	if err := v.RegisterValidation("unique_in_slice", isUniqueInSlice); err != nil {
 		log.Fatal().Err(err).Msg("Error while registering unique validator")
 	}
+	if err := v.RegisterValidation("oneof_csv", isOneOfCsv); err != nil {
+		log.Fatal().Err(err).Msg("Error while registering oneof_csv validator")
+	}
+	if err := v.RegisterValidation("slug", isSlug); err != nil {
+		log.Fatal().Err(err).Msg("Error while registering slug validator")
+	}
 
 	validatorCustom.validator = v
 	// validatorCustom.trans = trans
@@ -128,3 +135,34 @@ func isUniqueInSlice(fl validator.FieldLevel) bool {
 	}
 	return true
 }
+
+// isOneOfCsv checks that every comma separated value is one of the space separated params,
+// ex: "category,brand" is valid for `oneof_csv=category brand price`.
+func isOneOfCsv(fl validator.FieldLevel) bool {
+	allowed := strings.Fields(fl.Param())
+
+	for _, value := range strings.Split(fl.Field().String(), ",") {
+		value = strings.TrimSpace(value)
+
+		found := false
+		for _, a := range allowed {
+			if value == a {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+var slugRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// isSlug checks for lowercase letters and digits separated by single dashes, ex: "home-living".
+func isSlug(fl validator.FieldLevel) bool {
+	return slugRegex.MatchString(fl.Field().String())
+}
@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+var ErrPrivateAddress = errors.New("webhook url resolves to a private address")
+
+// Request is a delivery of an event to the url of a webhook.
+type Request struct {
+	Url        string
+	Secret     string
+	Event      string
+	DeliveryId string
+	Body       []byte
+}
+
+// Client posts the signed deliveries. Redirects are not followed and, unless allowed,
+// the urls resolving to loopback, private or link-local addresses are refused.
+type Client struct {
+	http      *http.Client
+	userAgent string
+}
+
+func NewClient(timeout time.Duration, allowPrivate bool, userAgent string) *Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = denyPrivate
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil
+
+	return &Client{
+		http: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		userAgent: userAgent,
+	}
+}
+
+// Send posts a delivery and returns the status of the response, any status other than 2xx is an error.
+func (c *Client) Send(ctx context.Context, req *Request) (int, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.Url, bytes.NewReader(req.Body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().UTC().Unix()
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", c.userAgent)
+	httpReq.Header.Set(HeaderEvent, req.Event)
+	httpReq.Header.Set(HeaderDelivery, req.DeliveryId)
+	httpReq.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, req.Body, timestamp))
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// drain a bounded part of the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// denyPrivate refuses the connections to the addresses of the internal network, it runs after the
+// host is resolved so that a public name pointing to an internal address is refused too.
+func denyPrivate(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return ErrPrivateAddress
+	}
+
+	return nil
+}
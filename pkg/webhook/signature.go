@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Headers of a delivery, the receiver checks the signature with Verify before trusting the body.
+const (
+	HeaderEvent     = "X-Shopeefun-Event"
+	HeaderDelivery  = "X-Shopeefun-Delivery"
+	HeaderTimestamp = "X-Shopeefun-Timestamp"
+	HeaderSignature = "X-Shopeefun-Signature"
+)
+
+// Sign returns the hex HMAC-SHA256 of the body followed by the unix timestamp, keyed with the secret of the webhook.
+func Sign(secret string, body []byte, timestamp int64) string {
+	data := fmt.Sprintf("%s%d", body, timestamp)
+
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(data))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Verify checks the timestamp and signature headers of a delivery, a delivery older than tolerance is refused.
+func Verify(secret string, body []byte, timestamp, signature string, tolerance time.Duration) bool {
+	sentAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	if time.Since(time.Unix(sentAt, 0)).Abs() > tolerance {
+		return false
+	}
+
+	expected := Sign(secret, body, sentAt)
+
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
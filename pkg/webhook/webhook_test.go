@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	var (
+		body      = []byte(`{"type":"product.updated"}`)
+		timestamp = time.Now().Unix()
+		signature = Sign("secret", body, timestamp)
+		sentAt    = strconv.FormatInt(timestamp, 10)
+	)
+
+	assert.Len(t, signature, 64)
+	assert.True(t, Verify("secret", body, sentAt, signature, time.Minute))
+	assert.False(t, Verify("other", body, sentAt, signature, time.Minute))
+	assert.False(t, Verify("secret", []byte(`{"type":"shop.updated"}`), sentAt, signature, time.Minute))
+	assert.False(t, Verify("secret", body, "not a time", signature, time.Minute))
+
+	old := time.Now().Add(-time.Hour).Unix()
+	assert.False(t, Verify("secret", body, strconv.FormatInt(old, 10), Sign("secret", body, old), time.Minute))
+}
+
+func TestSend(t *testing.T) {
+	var received *http.Request
+	var receivedBody []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(time.Second, true, "shopeefun-test")
+	req := &Request{Url: server.URL, Secret: "secret", Event: "stock.changed", DeliveryId: "d1", Body: []byte(`{"id":"e1"}`)}
+
+	status, err := client.Send(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, status)
+
+	assert.Equal(t, "stock.changed", received.Header.Get(HeaderEvent))
+	assert.Equal(t, "d1", received.Header.Get(HeaderDelivery))
+	assert.Equal(t, `{"id":"e1"}`, string(receivedBody))
+	assert.True(t, Verify("secret", receivedBody, received.Header.Get(HeaderTimestamp), received.Header.Get(HeaderSignature), time.Minute))
+}
+
+func TestSendFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/elsewhere", http.StatusFound)
+	}))
+	defer server.Close()
+
+	status, err := NewClient(time.Second, true, "shopeefun-test").Send(context.Background(), &Request{Url: server.URL, Body: []byte(`{}`)})
+	assert.Equal(t, http.StatusFound, status)
+	assert.EqualError(t, err, "unexpected status 302")
+}
+
+func TestSendPrivateAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, err := NewClient(time.Second, false, "shopeefun-test").Send(context.Background(), &Request{Url: server.URL, Body: []byte(`{}`)})
+	assert.ErrorIs(t, err, ErrPrivateAddress)
+}
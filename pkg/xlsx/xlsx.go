@@ -0,0 +1,138 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ContentType is the media type of a workbook.
+const ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// parts of the workbook written before the sheet, they never change.
+var parts = []struct{ name, body string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`},
+}
+
+// Writer streams a workbook of a single sheet, every row is written as it comes so that
+// a large sheet never has to be held in memory. Strings are written inline, without a shared strings table.
+type Writer struct {
+	zip   *zip.Writer
+	sheet *bufio.Writer
+}
+
+// NewWriter starts a workbook with one sheet named sheetName, Close must be called to complete it.
+func NewWriter(w io.Writer, sheetName string) (*Writer, error) {
+	zw := zip.NewWriter(w)
+
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+
+		body := part.body
+		if part.name == "xl/workbook.xml" {
+			body = fmt.Sprintf(body, escape(sheetName))
+		}
+
+		if _, err := io.WriteString(f, body); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := bufio.NewWriter(f)
+	_, err = sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Writer{zip: zw, sheet: sheet}, nil
+}
+
+// WriteRow appends a row. Numbers are written as numbers, nil as an empty cell and anything else as text.
+func (w *Writer) WriteRow(cells ...any) error {
+	w.sheet.WriteString("<row>")
+
+	for _, cell := range cells {
+		switch v := cell.(type) {
+		case nil:
+			w.sheet.WriteString("<c/>")
+		case int:
+			w.number(strconv.Itoa(v))
+		case int64:
+			w.number(strconv.FormatInt(v, 10))
+		case float64:
+			w.number(strconv.FormatFloat(v, 'f', -1, 64))
+		case bool:
+			w.text(strconv.FormatBool(v))
+		case time.Time:
+			w.text(v.Format(time.RFC3339))
+		case string:
+			w.text(v)
+		case *string:
+			if v == nil {
+				w.sheet.WriteString("<c/>")
+				continue
+			}
+			w.text(*v)
+		default:
+			w.text(fmt.Sprint(v))
+		}
+	}
+
+	_, err := w.sheet.WriteString("</row>")
+	return err
+}
+
+func (w *Writer) number(v string) {
+	w.sheet.WriteString("<c><v>" + v + "</v></c>")
+}
+
+func (w *Writer) text(v string) {
+	w.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">` + escape(v) + "</t></is></c>")
+}
+
+// Close completes the sheet and the workbook, it does not close the underlying writer.
+func (w *Writer) Close() error {
+	if _, err := w.sheet.WriteString("</sheetData></worksheet>"); err != nil {
+		return err
+	}
+
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+
+	return w.zip.Close()
+}
+
+// escape escapes the XML special characters of a text, invalid XML characters are replaced.
+func escape(s string) string {
+	var b xmlBuilder
+	_ = xml.EscapeText(&b, []byte(s))
+	return string(b)
+}
+
+type xmlBuilder []byte
+
+func (b *xmlBuilder) Write(p []byte) (int, error) {
+	*b = append(*b, p...)
+	return len(p), nil
+}
@@ -0,0 +1,44 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := NewWriter(&buf, "Products")
+	require.NoError(t, err)
+	require.NoError(t, w.WriteRow("name", "price"))
+	require.NoError(t, w.WriteRow("Tom & Jerry <3", 12.5))
+	require.NoError(t, w.WriteRow(nil, 3))
+	require.NoError(t, w.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		body, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(body)
+	}
+
+	assert.Contains(t, files, "[Content_Types].xml")
+	assert.Contains(t, files["xl/workbook.xml"], `<sheet name="Products"`)
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<t xml:space="preserve">Tom &amp; Jerry &lt;3</t>`)
+	assert.Contains(t, sheet, "<c><v>12.5</v></c>")
+	assert.Contains(t, sheet, "<row><c/><c><v>3</v></c></row>")
+	assert.Contains(t, sheet, "</sheetData></worksheet>")
+}